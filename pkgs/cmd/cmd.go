@@ -2,7 +2,11 @@ package cmd
 
 import (
 	"os"
+	"strings"
 
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/gvc/pkgs/utils"
+	"github.com/moqsien/gvc/pkgs/vctrl"
 	"github.com/urfave/cli/v2"
 )
 
@@ -21,6 +25,7 @@ func New() *Cmder {
 			Commands:    []*cli.Command{},
 		},
 	}
+	c.setFlags()
 	c.initiate()
 	return c
 }
@@ -30,7 +35,70 @@ func (c *Cmder) RunApp() {
 	c.Run(args)
 }
 
+// global flags answering interactive prompts.
+func (that *Cmder) setFlags() {
+	var targets string
+	that.Flags = []cli.Flag{
+		&cli.BoolFlag{
+			Name:        "yes",
+			Aliases:     []string{"y"},
+			Usage:       "Answer yes to confirms and choose the first option for selectors, never prompt.",
+			Destination: &utils.Answers.AssumeYes,
+		},
+		&cli.BoolFlag{
+			Name:        "non-interactive",
+			Usage:       "Never prompt, exit with an error when an answer is missing.",
+			EnvVars:     []string{utils.NonInteractiveEnvName},
+			Destination: &utils.Answers.NonInteractive,
+		},
+		&cli.BoolFlag{
+			Name:        "insecure-skip-verify",
+			Usage:       "Install downloads without verifying published checksums.",
+			Destination: &utils.InsecureSkipVerify,
+		},
+		&cli.StringFlag{
+			Name:        "source",
+			Usage:       "Download source, like go.dev, golang.google.cn, oracle.com, injdk.cn, flutter-io.cn, julialang.org.",
+			Destination: &utils.Answers.Source,
+		},
+		&cli.StringFlag{
+			Name:        "mirror",
+			Usage:       "Use mirrors for download acceleration or not [yes|no].",
+			Destination: &utils.Answers.Mirror,
+		},
+		&cli.StringFlag{
+			Name:        "compress",
+			Usage:       "Compress binaries built by gvc go build or not [yes|no].",
+			Destination: &utils.Answers.Compress,
+		},
+		&cli.StringFlag{
+			Name:        "targets",
+			Usage:       "Comma-separated os/arch list for gvc go build, like linux/amd64,darwin/arm64.",
+			Destination: &targets,
+		},
+		&cli.IntFlag{
+			Name:        "parallel",
+			Usage:       "Number of targets compiled at the same time by gvc go build, defaults to the number of cpus.",
+			Destination: &utils.Answers.Parallel,
+		},
+	}
+	that.Before = func(ctx *cli.Context) error {
+		for _, t := range strings.Split(targets, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				utils.Answers.Targets = append(utils.Answers.Targets, t)
+			}
+		}
+		return nil
+	}
+}
+
 func (that *Cmder) initiate() {
+	that.vsdk()
+	that.vhook()
+	that.vcache()
+	that.vmirror()
+	that.vkeyring()
+	that.vtools()
 	that.vgo()
 	that.vprotobuf()
 	that.vpython()
@@ -43,6 +111,7 @@ func (that *Cmder) initiate() {
 	that.vrust()
 	that.vcpp()
 	that.vtypst()
+	that.vzig()
 	that.vlang()
 
 	that.vscode()
@@ -67,3 +136,82 @@ func (that *Cmder) initiate() {
 	that.showinfo()
 	that.uninstall()
 }
+
+// flags for machine-readable output of listing commands.
+func outputFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "Output in json.",
+			Action: func(ctx *cli.Context, b bool) error {
+				if b {
+					vctrl.OutputFormat = vctrl.OutputJSON
+				}
+				return nil
+			},
+		},
+		&cli.BoolFlag{
+			Name:  "plain",
+			Usage: "Output tab-separated plain text without colors.",
+			Action: func(ctx *cli.Context, b bool) error {
+				if b {
+					vctrl.OutputFormat = vctrl.OutputPlain
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// adopts sdks installed by the system, like openjdk from apt or go from homebrew.
+func adoptCommand(sdkName string) *cli.Command {
+	return &cli.Command{
+		Name:      "adopt",
+		Usage:     "Adopt sdks installed by the system, system installs are scanned if no path is specified.",
+		ArgsUsage: "[path...]",
+		Flags: append([]cli.Flag{
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"n"},
+				Usage:   "Show found installs without adopting them.",
+			},
+		}, outputFlags()...),
+		Action: func(ctx *cli.Context) error {
+			if _, err := vctrl.AdoptSystemSDKs(sdkName, ctx.Args().Slice(), ctx.Bool("dry-run")); err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+}
+
+// installs an sdk from a local archive file, for machines without internet access.
+func installFromFileCommand(sdkName string) *cli.Command {
+	return &cli.Command{
+		Name:      "install",
+		Aliases:   []string{"ins", "i"},
+		Usage:     "Install from a local archive file, like: --from-file go1.21.5.linux-amd64.tar.gz.",
+		ArgsUsage: "[version]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "from-file",
+				Aliases:  []string{"f"},
+				Usage:    "Local archive file to install.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "checksum",
+				Usage: "Checksum of the archive, like sha256:<hex>. Files like <archive>.sha256 are used if not specified.",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			_, err := vctrl.InstallFromFile(sdkName, ctx.Args().First(), ctx.String("from-file"), ctx.String("checksum"))
+			if err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+}
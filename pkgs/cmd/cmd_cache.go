@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/gvc/pkgs/vctrl"
+	"github.com/urfave/cli/v2"
+)
+
+func (that *Cmder) vcache() {
+	command := &cli.Command{
+		Name:        "cache",
+		Usage:       "Manage the shared download cache.",
+		Subcommands: []*cli.Command{},
+	}
+
+	ls := &cli.Command{
+		Name:    "ls",
+		Aliases: []string{"l"},
+		Usage:   "List cached files.",
+		Flags:   outputFlags(),
+		Action: func(ctx *cli.Context) error {
+			vctrl.ShowCache()
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, ls)
+
+	verify := &cli.Command{
+		Name:    "verify",
+		Aliases: []string{"v"},
+		Usage:   "Re-hash cached files against recorded checksums, broken files are removed.",
+		Action: func(ctx *cli.Context) error {
+			if broken := vctrl.VerifyCache(); broken > 0 {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, verify)
+
+	prune := &cli.Command{
+		Name:    "prune",
+		Aliases: []string{"p"},
+		Usage:   "Remove cached files by age or total size.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "older-than",
+				Usage: "Remove files unused for a duration, like: 30d, 2w, 12h.",
+			},
+			&cli.StringFlag{
+				Name:  "max-size",
+				Usage: "Remove the least recently used files until the cache fits in a size, like: 2GB, 500MB.",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			var (
+				olderThan time.Duration
+				maxSize   int64
+				err       error
+			)
+			if s := ctx.String("older-than"); s != "" {
+				if olderThan, err = vctrl.ParseAge(s); err != nil {
+					gprint.PrintError("%+v", err)
+					os.Exit(1)
+				}
+			}
+			if s := ctx.String("max-size"); s != "" {
+				if maxSize, err = vctrl.ParseSize(s); err != nil {
+					gprint.PrintError("%+v", err)
+					os.Exit(1)
+				}
+			}
+			if olderThan == 0 && maxSize == 0 {
+				gprint.PrintWarning("Nothing to do, use --older-than or --max-size.")
+				return nil
+			}
+			vctrl.PruneCache(olderThan, maxSize)
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, prune)
+
+	that.Commands = append(that.Commands, command)
+}
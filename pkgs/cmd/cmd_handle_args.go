@@ -6,13 +6,41 @@ const (
 	tempChar string = "@"
 )
 
+// global flags that take a value.
+var valueFlags = map[string]struct{}{
+	"source":   {},
+	"mirror":   {},
+	"compress": {},
+	"targets":  {},
+	"parallel": {},
+}
+
+// takesValue reports whether a global flag reads its value from the next argument.
+// "--targets=linux/amd64" carries its own value, "-targets" is the same as "--targets".
+func takesValue(arg string) bool {
+	if strings.Contains(arg, "=") {
+		return false
+	}
+	_, ok := valueFlags[strings.TrimLeft(arg, "-")]
+	return ok
+}
+
 func HandleArgs(args ...string) (aList []string) {
-	if len(args) < 4 {
+	// skip global flags before the command.
+	idx := 1
+	for idx < len(args) && strings.HasPrefix(args[idx], "-") {
+		if takesValue(args[idx]) {
+			idx++
+		}
+		idx++
+	}
+	if len(args) < idx+3 {
 		return args
 	}
 	// for "gvc go build"
-	if (args[1] == "go" || args[1] == "g") && (args[2] == "build" || args[2] == "bui" || args[2] == "b") {
-		for _, v := range args {
+	if (args[idx] == "go" || args[idx] == "g") && (args[idx+1] == "build" || args[idx+1] == "bui" || args[idx+1] == "b") {
+		aList = append(aList, args[:idx+2]...)
+		for _, v := range args[idx+2:] {
 			if strings.HasPrefix(v, "-") && !strings.Contains(v, " ") {
 				aList = append(aList, strings.Replace(v, "-", tempChar, 1))
 			} else {
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/gvc/pkgs/vctrl"
+	"github.com/urfave/cli/v2"
+)
+
+func (that *Cmder) vhook() {
+	hook := &cli.Command{
+		Name:      "hook",
+		Usage:     `Print a shell hook that switches pinned versions on cd, like: eval "$(gvc hook bash)".`,
+		ArgsUsage: "bash|zsh|fish",
+		Action: func(ctx *cli.Context) error {
+			script, err := vctrl.HookScript(ctx.Args().First())
+			if err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			fmt.Print(script)
+			return nil
+		},
+	}
+	that.Commands = append(that.Commands, hook)
+
+	hookEnv := &cli.Command{
+		Name:      "hook-env",
+		Usage:     "Print envs for versions pinned in the present working directory, used by shell hooks.",
+		ArgsUsage: "bash|zsh|fish",
+		Hidden:    true,
+		Action: func(ctx *cli.Context) error {
+			cwd, _ := os.Getwd()
+			content, err := vctrl.HookEnv(ctx.Args().First(), cwd)
+			if err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			if content != "" {
+				fmt.Println(content)
+			}
+			return nil
+		},
+	}
+	that.Commands = append(that.Commands, hookEnv)
+}
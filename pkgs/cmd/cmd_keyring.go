@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/gvc/pkgs/vctrl"
+	"github.com/urfave/cli/v2"
+)
+
+func (that *Cmder) vkeyring() {
+	command := &cli.Command{
+		Name:        "keyring",
+		Usage:       "Manage OpenPGP keys for verifying go and nodejs releases.",
+		Subcommands: []*cli.Command{},
+	}
+
+	ls := &cli.Command{
+		Name:    "ls",
+		Aliases: []string{"l"},
+		Usage:   "List bundled and user keys.",
+		Flags:   outputFlags(),
+		Action: func(ctx *cli.Context) error {
+			vctrl.ShowKeyring()
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, ls)
+
+	imp := &cli.Command{
+		Name:      "import",
+		Aliases:   []string{"i"},
+		Usage:     "Import public keys from local files into the user keyring.",
+		ArgsUsage: "<file>...",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() == 0 {
+				gprint.PrintError("No key file specified.")
+				os.Exit(1)
+			}
+			for _, fpath := range ctx.Args().Slice() {
+				if err := vctrl.ImportKey(fpath); err != nil {
+					gprint.PrintError("%+v", err)
+					os.Exit(1)
+				}
+			}
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, imp)
+
+	update := &cli.Command{
+		Name:    "update",
+		Aliases: []string{"u"},
+		Usage:   "Download release keys of go and nodejs into the user keyring.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "dir",
+				Usage: "Save keys into the given directory instead, like pkgs/vctrl/keyrings for bundled keys.",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if vctrl.UpdateKeyring(ctx.String("dir")) == 0 {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, update)
+
+	that.Commands = append(that.Commands, command)
+}
@@ -3,8 +3,10 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	config "github.com/moqsien/gvc/pkgs/confs"
 	"github.com/moqsien/gvc/pkgs/utils"
 	"github.com/moqsien/gvc/pkgs/utils/sorts"
 	"github.com/moqsien/gvc/pkgs/vctrl"
@@ -22,14 +24,14 @@ func (that *Cmder) vgo() {
 	vremote := &cli.Command{
 		Name:    "remote",
 		Aliases: []string{"r"},
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:        "show-all",
 				Aliases:     []string{"a", "all"},
 				Usage:       "Show all remote versions.",
 				Destination: &showall,
 			},
-		},
+		}, outputFlags()...),
 		Usage: "Show remote versions.",
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewGoVersion()
@@ -46,9 +48,10 @@ func (that *Cmder) vgo() {
 	vuse := &cli.Command{
 		Name:    "use",
 		Aliases: []string{"u"},
-		Usage:   "Download and use version.",
+		Usage:   "Download and use version, like: 1.21.5, 1.21, ~1.20, \">=1.21 <1.22\", latest, stable.",
 		Action: func(ctx *cli.Context) error {
-			version := ctx.Args().First()
+			// constraints like ">=1.21 <1.22" may be passed without quotes.
+			version := strings.Join(ctx.Args().Slice(), " ")
 			if version != "" {
 				gv := vctrl.NewGoVersion()
 				gv.UseVersion(version)
@@ -62,6 +65,7 @@ func (that *Cmder) vgo() {
 		Name:    "local",
 		Aliases: []string{"l"},
 		Usage:   "Show installed versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewGoVersion()
 			gv.ShowInstalled()
@@ -153,11 +157,9 @@ func (that *Cmder) vgo() {
 		Usage:   `Compiles go code for multi-platforms [with <-ldflags "-s -w"> builtin].`,
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewGoVersion()
-			args := []string{}
-			if len(os.Args) > 3 {
-				args = os.Args[3:]
+			if !gv.Build(RecoverArgs(ctx.Args().Slice()...)...) {
+				os.Exit(1)
 			}
-			gv.Build(RecoverArgs(args...)...)
 			return nil
 		},
 	}
@@ -167,6 +169,13 @@ func (that *Cmder) vgo() {
 		Name:    "renameTo",
 		Aliases: []string{"rnt", "rto"},
 		Usage:   `Rename a local go module[gvc go rto NEW_MODULE_NAME].`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"n"},
+				Usage:   "Print a unified diff without changing files.",
+			},
+		},
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewGoVersion()
 			newName := ctx.Args().First()
@@ -175,7 +184,10 @@ func (that *Cmder) vgo() {
 				gprint.PrintError("Can not find go.mod in current working dir.")
 				return nil
 			}
-			gv.RenameLocalModule(moduleDir, newName)
+			if err := gv.RenameLocalModule(moduleDir, newName, ctx.Bool("dry-run")); err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
 			return nil
 		},
 	}
@@ -193,6 +205,9 @@ func (that *Cmder) vgo() {
 	}
 	command.Subcommands = append(command.Subcommands, gdist)
 
+	command.Subcommands = append(command.Subcommands, installFromFileCommand("go"))
+	command.Subcommands = append(command.Subcommands, adoptCommand("go"))
+
 	that.Commands = append(that.Commands, command)
 }
 
@@ -207,7 +222,7 @@ func (that *Cmder) vjava() {
 	vuse := &cli.Command{
 		Name:    "use",
 		Aliases: []string{"u"},
-		Usage:   "Download and use jdk.}",
+		Usage:   "Download and use jdk, like: jdk21, temurin@21, zulu@17.0.9, corretto@21, graalvm@21, system@11.",
 		Action: func(ctx *cli.Context) error {
 			version := ctx.Args().First()
 			if version != "" {
@@ -223,6 +238,7 @@ func (that *Cmder) vjava() {
 		Name:    "remote",
 		Aliases: []string{"r"},
 		Usage:   "Show available versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewJDKVersion()
 			gv.ShowVersions()
@@ -235,6 +251,7 @@ func (that *Cmder) vjava() {
 		Name:    "local",
 		Aliases: []string{"l"},
 		Usage:   "Show installed versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewJDKVersion()
 			gv.ShowInstalled()
@@ -269,6 +286,9 @@ func (that *Cmder) vjava() {
 		},
 	}
 	command.Subcommands = append(command.Subcommands, vrmall)
+	command.Subcommands = append(command.Subcommands, installFromFileCommand("java"))
+	command.Subcommands = append(command.Subcommands, adoptCommand("java"))
+
 	that.Commands = append(that.Commands, command)
 }
 
@@ -299,6 +319,7 @@ func (that *Cmder) vmaven() {
 		Name:    "remote",
 		Aliases: []string{"r"},
 		Usage:   "Show available versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewMavenVersion()
 			gv.ShowVersions()
@@ -311,6 +332,7 @@ func (that *Cmder) vmaven() {
 		Name:    "local",
 		Aliases: []string{"l"},
 		Usage:   "Show installed versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewMavenVersion()
 			gv.ShowInstalled()
@@ -322,7 +344,7 @@ func (that *Cmder) vmaven() {
 	vset := &cli.Command{
 		Name:    "set",
 		Aliases: []string{"se"},
-		Usage:   "Set mirrors and local repository path.",
+		Usage:   "Set the mirror in use and local repository path.",
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewMavenVersion()
 			gv.GenSettingsFile()
@@ -331,6 +353,101 @@ func (that *Cmder) vmaven() {
 	}
 	command.Subcommands = append(command.Subcommands, vset)
 
+	vmirror := &cli.Command{
+		Name:        "mirror",
+		Aliases:     []string{"mi"},
+		Usage:       "Manage mirrors in settings.xml.",
+		Subcommands: []*cli.Command{},
+	}
+	vmirror.Subcommands = append(vmirror.Subcommands, &cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls"},
+		Usage:   "Show mirrors.",
+		Flags:   outputFlags(),
+		Action: func(ctx *cli.Context) error {
+			gv := vctrl.NewMavenVersion()
+			gv.ShowMirrors()
+			return nil
+		},
+	})
+	vmirror.Subcommands = append(vmirror.Subcommands, &cli.Command{
+		Name:      "add",
+		Usage:     "Add or replace a mirror, like: add nexus https://nexus.example.com/repository/maven-public/.",
+		ArgsUsage: "<name> <url>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "id",
+				Usage: "Id of the mirror and the server, the name is used if not specified.",
+			},
+			&cli.StringFlag{
+				Name:  "mirror-of",
+				Usage: "Repositories to mirror.",
+				Value: "*",
+			},
+			&cli.StringFlag{
+				Name:  "username",
+				Usage: "Username of the server.",
+			},
+			&cli.StringFlag{
+				Name:  "password",
+				Usage: "Password of the server, like ${env.NEXUS_PASSWORD}.",
+			},
+			&cli.BoolFlag{
+				Name:  "snapshots",
+				Usage: "Enable snapshots of the repository.",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.Args().Len() != 2 {
+				gprint.PrintError("Please specify a name and an url.")
+				os.Exit(1)
+			}
+			gv := vctrl.NewMavenVersion()
+			err := gv.AddMirror(ctx.Args().Get(0), &config.MavenMirror{
+				Id:        ctx.String("id"),
+				Url:       ctx.Args().Get(1),
+				MirrorOf:  ctx.String("mirror-of"),
+				Username:  ctx.String("username"),
+				Password:  ctx.String("password"),
+				Snapshots: ctx.Bool("snapshots"),
+			})
+			if err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	})
+	vmirror.Subcommands = append(vmirror.Subcommands, &cli.Command{
+		Name:      "use",
+		Aliases:   []string{"u"},
+		Usage:     "Switch settings.xml to a mirror.",
+		ArgsUsage: "<name>",
+		Action: func(ctx *cli.Context) error {
+			gv := vctrl.NewMavenVersion()
+			if err := gv.UseMirror(ctx.Args().First()); err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	})
+	vmirror.Subcommands = append(vmirror.Subcommands, &cli.Command{
+		Name:      "remove",
+		Aliases:   []string{"rm"},
+		Usage:     "Remove a mirror.",
+		ArgsUsage: "<name>",
+		Action: func(ctx *cli.Context) error {
+			gv := vctrl.NewMavenVersion()
+			if err := gv.RemoveMirror(ctx.Args().First()); err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	})
+	command.Subcommands = append(command.Subcommands, vmirror)
+
 	vrm := &cli.Command{
 		Name:    "remove",
 		Aliases: []string{"rm"},
@@ -357,6 +474,27 @@ func (that *Cmder) vmaven() {
 		},
 	}
 	command.Subcommands = append(command.Subcommands, vrmall)
+	vprefetch := &cli.Command{
+		Name:      "wrapper-prefetch",
+		Aliases:   []string{"wp"},
+		Usage:     "Download the distribution of the maven wrapper in a project, so that the wrapper starts offline.",
+		ArgsUsage: "[project dir]",
+		Action: func(ctx *cli.Context) error {
+			projectDir := ctx.Args().First()
+			if projectDir == "" {
+				projectDir, _ = os.Getwd()
+			}
+			gv := vctrl.NewMavenVersion()
+			if err := gv.PrefetchWrapper(projectDir); err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, vprefetch)
+	command.Subcommands = append(command.Subcommands, installFromFileCommand("maven"))
+
 	that.Commands = append(that.Commands, command)
 }
 
@@ -387,6 +525,7 @@ func (that *Cmder) vgradle() {
 		Name:    "remote",
 		Aliases: []string{"r"},
 		Usage:   "Show available versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewGradleVersion()
 			gv.ShowVersions()
@@ -399,6 +538,7 @@ func (that *Cmder) vgradle() {
 		Name:    "local",
 		Aliases: []string{"l"},
 		Usage:   "Show installed versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewGradleVersion()
 			gv.ShowInstalled()
@@ -445,6 +585,27 @@ func (that *Cmder) vgradle() {
 		},
 	}
 	command.Subcommands = append(command.Subcommands, vrmall)
+	vprefetch := &cli.Command{
+		Name:      "wrapper-prefetch",
+		Aliases:   []string{"wp"},
+		Usage:     "Download the distribution of the gradle wrapper in a project, so that the wrapper starts offline.",
+		ArgsUsage: "[project dir]",
+		Action: func(ctx *cli.Context) error {
+			projectDir := ctx.Args().First()
+			if projectDir == "" {
+				projectDir, _ = os.Getwd()
+			}
+			gv := vctrl.NewGradleVersion()
+			if err := gv.PrefetchWrapper(projectDir); err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, vprefetch)
+	command.Subcommands = append(command.Subcommands, installFromFileCommand("gradle"))
+
 	that.Commands = append(that.Commands, command)
 }
 
@@ -459,6 +620,7 @@ func (that *Cmder) vpython() {
 		Name:    "remote",
 		Aliases: []string{"r"},
 		Usage:   "Show remote versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			nv := vctrl.NewPyVenv()
 			nv.ListRemoteVersions()
@@ -499,6 +661,7 @@ func (that *Cmder) vpython() {
 		Name:    "local",
 		Aliases: []string{"l"},
 		Usage:   "Show installed versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			nv := vctrl.NewPyVenv()
 			nv.ShowInstalled()
@@ -571,6 +734,7 @@ func (that *Cmder) vnodejs() {
 		Name:    "remote",
 		Aliases: []string{"r"},
 		Usage:   "Show remote versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			nv := vctrl.NewNodeVersion()
 			nv.ShowVersions()
@@ -582,9 +746,10 @@ func (that *Cmder) vnodejs() {
 	vuse := &cli.Command{
 		Name:    "use",
 		Aliases: []string{"u"},
-		Usage:   "Download and use version.",
+		Usage:   "Download and use version, like: 20.11.0, 20, lts, lts/iron, latest.",
 		Action: func(ctx *cli.Context) error {
-			version := ctx.Args().First()
+			// constraints like ">=20 <22" may be passed without quotes.
+			version := strings.Join(ctx.Args().Slice(), " ")
 			if version != "" {
 				nv := vctrl.NewNodeVersion()
 				nv.UseVersion(version)
@@ -598,6 +763,7 @@ func (that *Cmder) vnodejs() {
 		Name:    "local",
 		Aliases: []string{"l"},
 		Usage:   "Show installed versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			nv := vctrl.NewNodeVersion()
 			nv.ShowInstalled()
@@ -632,6 +798,9 @@ func (that *Cmder) vnodejs() {
 	}
 	command.Subcommands = append(command.Subcommands, rmversion)
 
+	command.Subcommands = append(command.Subcommands, installFromFileCommand("nodejs"))
+	command.Subcommands = append(command.Subcommands, adoptCommand("nodejs"))
+
 	that.Commands = append(that.Commands, command)
 }
 
@@ -763,6 +932,7 @@ func (that *Cmder) vflutter() {
 		Name:    "remote",
 		Aliases: []string{"r"},
 		Usage:   "Show available versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewFlutterVersion()
 			gv.ShowVersions()
@@ -775,6 +945,7 @@ func (that *Cmder) vflutter() {
 		Name:    "local",
 		Aliases: []string{"l"},
 		Usage:   "Show installed versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewFlutterVersion()
 			gv.ShowInstalled()
@@ -860,6 +1031,8 @@ func (that *Cmder) vflutter() {
 	}
 	command.Subcommands = append(command.Subcommands, vreplace)
 
+	command.Subcommands = append(command.Subcommands, installFromFileCommand("flutter"))
+
 	that.Commands = append(that.Commands, command)
 }
 
@@ -890,6 +1063,7 @@ func (that *Cmder) vjulia() {
 		Name:    "remote",
 		Aliases: []string{"r"},
 		Usage:   "Show available versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewJuliaVersion()
 			gv.ShowVersions()
@@ -902,6 +1076,7 @@ func (that *Cmder) vjulia() {
 		Name:    "local",
 		Aliases: []string{"l"},
 		Usage:   "Show installed versions.",
+		Flags:   outputFlags(),
 		Action: func(ctx *cli.Context) error {
 			gv := vctrl.NewJuliaVersion()
 			gv.ShowInstalled()
@@ -936,6 +1111,8 @@ func (that *Cmder) vjulia() {
 		},
 	}
 	command.Subcommands = append(command.Subcommands, vrmall)
+	command.Subcommands = append(command.Subcommands, installFromFileCommand("julia"))
+
 	that.Commands = append(that.Commands, command)
 }
 
@@ -981,6 +1158,48 @@ func (that *Cmder) vtypst() {
 	that.Commands = append(that.Commands, command)
 }
 
+func (that *Cmder) vzig() {
+	command := &cli.Command{
+		Name:        "zig",
+		Aliases:     []string{"zi"},
+		Usage:       "Zig installation, also used as the cgo cross compiler of gvc go build.",
+		Subcommands: []*cli.Command{},
+	}
+	var force bool
+	install := &cli.Command{
+		Name:    "install",
+		Aliases: []string{"ins", "i"},
+		Usage:   "Install the latest zig.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "Force to replace old version.",
+				Destination: &force,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			v := vctrl.NewZigVersion()
+			v.Install(force)
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, install)
+
+	setEnv := &cli.Command{
+		Name:    "setenv",
+		Aliases: []string{"env", "se", "e"},
+		Usage:   "Set env for zig.",
+		Action: func(ctx *cli.Context) error {
+			v := vctrl.NewZigVersion()
+			v.CheckAndInitEnv()
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, setEnv)
+	that.Commands = append(that.Commands, command)
+}
+
 func (that *Cmder) vlang() {
 	command := &cli.Command{
 		Name:        "vlang",
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/gvc/pkgs/vctrl"
+	"github.com/urfave/cli/v2"
+)
+
+func (that *Cmder) vmirror() {
+	command := &cli.Command{
+		Name:        "mirror",
+		Usage:       "Serve downloaded sdk archives to the LAN.",
+		Subcommands: []*cli.Command{},
+	}
+
+	serve := &cli.Command{
+		Name:    "serve",
+		Aliases: []string{"s"},
+		Usage:   "Serve archives with go, nodejs and julia compatible indexes.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "addr",
+				Value: ":8080",
+				Usage: "Address to listen on.",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if err := vctrl.NewMirrorServer(ctx.String("addr")).Serve(); err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, serve)
+
+	that.Commands = append(that.Commands, command)
+}
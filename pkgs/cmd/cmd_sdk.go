@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/gvc/pkgs/vctrl"
+	"github.com/urfave/cli/v2"
+)
+
+// getSDKManager parses <sdk>@<version> from args, gvc exits for unknown sdks.
+func getSDKManager(ctx *cli.Context) (m vctrl.SDKManager, version string) {
+	arg := ctx.Args().First()
+	if arg == "" {
+		gprint.PrintError(fmt.Sprintf("Please specify an sdk, like <sdk>@<version>. Available sdks: %s", strings.Join(vctrl.SDKNames(), ", ")))
+		os.Exit(1)
+	}
+	name, version := vctrl.ParseSDKArg(arg)
+	if version == "" && ctx.Args().Len() > 1 {
+		version = ctx.Args().Get(1)
+	}
+	m, err := vctrl.GetSDKManager(name)
+	if err != nil {
+		gprint.PrintError("%+v", err)
+		os.Exit(1)
+	}
+	return m, version
+}
+
+func requireVersion(version string) {
+	if version == "" {
+		gprint.PrintError("Please specify a version, like <sdk>@<version>.")
+		os.Exit(1)
+	}
+}
+
+func (that *Cmder) vsdk() {
+	install := &cli.Command{
+		Name:      "install",
+		Aliases:   []string{"ins"},
+		Usage:     "Install an sdk version without switching to it.",
+		ArgsUsage: "<sdk>@<version>",
+		Action: func(ctx *cli.Context) error {
+			m, version := getSDKManager(ctx)
+			requireVersion(version)
+			dir := m.InstallVersion(version)
+			if dir == "" {
+				os.Exit(1)
+			}
+			gprint.PrintSuccess(fmt.Sprintf("Installed %s in %s.", version, dir))
+			return nil
+		},
+	}
+	that.Commands = append(that.Commands, install)
+
+	var detect bool
+	use := &cli.Command{
+		Name:      "use",
+		Usage:     "Download and use an sdk version, or versions pinned in .gvc-versions without args.",
+		ArgsUsage: "[<sdk>@<version>]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "detect",
+				Aliases:     []string{"d"},
+				Usage:       "Also read .tool-versions, .go-version, .nvmrc, .python-version, .java-version, go.mod and package.json.",
+				Destination: &detect,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.Args().Len() == 0 {
+				if !vctrl.UsePinnedVersions(detect) {
+					os.Exit(1)
+				}
+				return nil
+			}
+			m, version := getSDKManager(ctx)
+			requireVersion(version)
+			if !m.UseVersion(version) {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	that.Commands = append(that.Commands, use)
+
+	exe := &cli.Command{
+		Name:            "exec",
+		Aliases:         []string{"x"},
+		Usage:           "Run a command under specific sdk versions without switching globally.",
+		ArgsUsage:       "<sdk>@<version>... -- <command> [args...]",
+		SkipFlagParsing: true,
+		Action: func(ctx *cli.Context) error {
+			specs, args := []string{}, []string{}
+			for idx, arg := range ctx.Args().Slice() {
+				if arg == "--" {
+					args = ctx.Args().Slice()[idx+1:]
+					break
+				}
+				specs = append(specs, arg)
+			}
+			if len(specs) == 0 {
+				gprint.PrintError("Please specify sdk versions, like: gvc exec go@1.21.5 -- go test ./...")
+				os.Exit(1)
+			}
+			os.Exit(vctrl.ExecWithVersions(specs, args))
+			return nil
+		},
+	}
+	that.Commands = append(that.Commands, exe)
+
+	var remote bool
+	ls := &cli.Command{
+		Name:      "ls",
+		Aliases:   []string{"list"},
+		Usage:     "Show installed versions of an sdk.",
+		ArgsUsage: "<sdk>",
+		Flags: append([]cli.Flag{
+			&cli.BoolFlag{
+				Name:        "remote",
+				Aliases:     []string{"r"},
+				Usage:       "Show remote versions.",
+				Destination: &remote,
+			},
+		}, outputFlags()...),
+		Action: func(ctx *cli.Context) error {
+			m, _ := getSDKManager(ctx)
+			if remote {
+				m.ShowVersions()
+			} else {
+				m.ShowInstalled()
+			}
+			return nil
+		},
+	}
+	that.Commands = append(that.Commands, ls)
+
+	var unused bool
+	rm := &cli.Command{
+		Name:      "rm",
+		Aliases:   []string{"remove"},
+		Usage:     "Remove an installed version of an sdk.",
+		ArgsUsage: "<sdk>@<version>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "unused",
+				Aliases:     []string{"u"},
+				Usage:       "Remove all unused versions.",
+				Destination: &unused,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			m, version := getSDKManager(ctx)
+			if unused {
+				m.RemoveUnused()
+				return nil
+			}
+			requireVersion(version)
+			m.RemoveVersion(version)
+			return nil
+		},
+	}
+	that.Commands = append(that.Commands, rm)
+}
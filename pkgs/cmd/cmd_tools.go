@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/gvc/pkgs/vctrl"
+	"github.com/urfave/cli/v2"
+)
+
+func (that *Cmder) vtools() {
+	langs := strings.Join(vctrl.ToolsLangs, "|")
+	command := &cli.Command{
+		Name:        "tools",
+		Usage:       "Manage global developer tools reinstalled after switching sdk versions.",
+		Subcommands: []*cli.Command{},
+	}
+
+	ls := &cli.Command{
+		Name:    "ls",
+		Aliases: []string{"l"},
+		Usage:   "List tools in the manifest.",
+		Flags:   outputFlags(),
+		Action: func(ctx *cli.Context) error {
+			vctrl.ShowTools()
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, ls)
+
+	add := &cli.Command{
+		Name:      "add",
+		Aliases:   []string{"a"},
+		Usage:     "Add tools to the manifest, like: gvc tools add go golang.org/x/tools/gopls@v0.14.2",
+		ArgsUsage: "<" + langs + "> <tool>...",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() < 2 {
+				gprint.PrintError("Please specify a language and tools.")
+				os.Exit(1)
+			}
+			if err := vctrl.AddTools(ctx.Args().First(), ctx.Args().Tail()...); err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, add)
+
+	rm := &cli.Command{
+		Name:      "rm",
+		Aliases:   []string{"r"},
+		Usage:     "Remove tools from the manifest.",
+		ArgsUsage: "<" + langs + "> <tool>...",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() < 2 {
+				gprint.PrintError("Please specify a language and tools.")
+				os.Exit(1)
+			}
+			if err := vctrl.RemoveTools(ctx.Args().First(), ctx.Args().Tail()...); err != nil {
+				gprint.PrintError("%+v", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, rm)
+
+	sync := &cli.Command{
+		Name:      "sync",
+		Aliases:   []string{"s"},
+		Usage:     "Reinstall tools with the sdks in use, for all languages by default.",
+		ArgsUsage: "[" + langs + "]...",
+		Action: func(ctx *cli.Context) error {
+			if failed := vctrl.SyncTools(ctx.Args().Slice()...); failed > 0 {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	command.Subcommands = append(command.Subcommands, sync)
+
+	that.Commands = append(that.Commands, command)
+}
@@ -34,6 +34,8 @@ type GVConfig struct {
 	Flutter  *FlutterConf         `koanf:"flutter"`
 	Julia    *JuliaConf           `koanf:"julia"`
 	Typst    *TypstConf           `koanf:"typst"`
+	Zig      *ZigConf             `koanf:"zig"`
+	Tools    *ToolsConf           `koanf:"tools"`
 	Webdav   *DavConf             `koanf:"dav"`
 	Protobuf *ProtobufConf        `koanf:"protobuf"`
 	GSudo    *GsudoConf           `koanf:"gsudo"`
@@ -64,6 +66,8 @@ func New() (r *GVConfig) {
 		Flutter:  NewFlutterConf(),
 		Julia:    NewJuliaConf(),
 		Typst:    NewTypstConf(),
+		Zig:      NewZigConf(),
+		Tools:    NewToolsConf(),
 		Webdav:   NewDavConf(),
 		Protobuf: NewProtobuf(),
 		GSudo:    NewGsudoConf(),
@@ -85,6 +89,10 @@ func (that *GVConfig) initiate() {
 		that.Reload()
 	} else {
 		gprint.PrintWarning("Cannot find default config files.")
+		if !utils.CanPrompt() {
+			that.Reset()
+			return
+		}
 		cfm := confirm.NewConfirm(confirm.WithTitle("Use the default config files now?"))
 		cfm.Run()
 		if cfm.Result() {
@@ -134,6 +142,10 @@ func (that *GVConfig) SetDefault() {
 	that.Julia.Reset()
 	that.Typst = NewTypstConf()
 	that.Typst.Reset()
+	that.Zig = NewZigConf()
+	that.Zig.Reset()
+	that.Tools = NewToolsConf()
+	that.Tools.Reset()
 	that.Webdav = NewDavConf()
 	that.Webdav.Reset()
 	that.Protobuf = NewProtobuf()
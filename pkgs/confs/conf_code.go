@@ -12,6 +12,7 @@ type CodeConf struct {
 	CdnUrl         string   `koanf:"cdn_url"`
 	DownloadUrl    string   `koanf:"download_url"`
 	ExtIdentifiers []string `koanf:"ext_identifiers"`
+	UseCdn         string   `koanf:"use_cdn"`
 	path           string
 }
 
@@ -17,6 +17,7 @@ type FlutterConf struct {
 	AndroidCMDTooolsUrl  string            `koanf:"android_cmd_toools_url"`
 	AndroidCN            string            `koanf:"android_cn_url"`
 	Android              string            `koanf:"android_url"`
+	DownloadSource       string            `koanf:"download_source"`
 	path                 string
 }
 
@@ -8,11 +8,15 @@ import (
 )
 
 type GoConf struct {
-	CompilerUrls []string `koanf:"compiler_urls"`
-	AliRepoUrl   string   `koanf:"ali_repo_url"`
-	Proxies      []string `koanf:"proxies"`
-	SearchUrl    string   `koanf:"search_url"`
-	path         string
+	CompilerUrls   []string `koanf:"compiler_urls"`
+	AliRepoUrl     string   `koanf:"ali_repo_url"`
+	Proxies        []string `koanf:"proxies"`
+	SearchUrl      string   `koanf:"search_url"`
+	DownloadSource string   `koanf:"download_source"`
+	UseMirror      string   `koanf:"use_mirror"`
+	KeyringUrls    []string `koanf:"keyring_urls"`
+	SignatureUrl   string   `koanf:"signature_url"`
+	path           string
 }
 
 func NewGoConf() (r *GoConf) {
@@ -43,4 +47,8 @@ func (that *GoConf) Reset() {
 		"https://repo.huaweicloud.com/repository/goproxy/,direct",
 	}
 	that.SearchUrl = `https://pkg.go.dev/search?limit=100&m=package&q=%s#more-results`
+	// go archives are signed by the google linux packages signing key.
+	that.KeyringUrls = []string{"https://dl.google.com/linux/linux_signing_key.pub"}
+	// signatures are not provided by mirrors, <archive>.asc is downloaded from here.
+	that.SignatureUrl = "https://dl.google.com/go/"
 }
@@ -8,9 +8,12 @@ import (
 )
 
 type JavaConf struct {
-	CompilerUrl string `koanf:"compiler_url"`
-	JDKUrl      string `koanf:"jdk_url"`
-	path        string
+	CompilerUrl    string `koanf:"compiler_url"`
+	JDKUrl         string `koanf:"jdk_url"`
+	DownloadSource string `koanf:"download_source"`
+	AdoptiumUrl    string `koanf:"adoptium_url"`
+	FoojayUrl      string `koanf:"foojay_url"`
+	path           string
 }
 
 func NewJavaConf() (r *JavaConf) {
@@ -32,4 +35,7 @@ func (that *JavaConf) setup() {
 func (that *JavaConf) Reset() {
 	that.CompilerUrl = "https://www.oracle.com/java/technologies/downloads/"
 	that.JDKUrl = "https://www.injdk.cn/"
+	// apis for vendor jdks, like temurin@21.
+	that.AdoptiumUrl = "https://api.adoptium.net/v3"
+	that.FoojayUrl = "https://api.foojay.io/disco/v3.0"
 }
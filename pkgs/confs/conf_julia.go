@@ -13,6 +13,7 @@ type JuliaConf struct {
 	MirrorUrls         []string `koanf:"mirror_urls"`
 	BaseUrl            string   `koanf:"base_url"`
 	PkgServer          string   `koanf:"pkg_server"`
+	DownloadSource     string   `koanf:"download_source"`
 	path               string
 }
 
@@ -7,11 +7,24 @@ import (
 	"github.com/moqsien/gvc/pkgs/utils"
 )
 
+// MavenMirror is written to settings.xml as a mirror, a server with credentials and a profile.
+type MavenMirror struct {
+	Id       string `koanf:"id"`
+	Url      string `koanf:"url"`
+	MirrorOf string `koanf:"mirror_of"`
+	// credentials of the server, like ${env.NEXUS_PASSWORD}.
+	Username  string `koanf:"username"`
+	Password  string `koanf:"password"`
+	Snapshots bool   `koanf:"snapshots"`
+}
+
 type MavenConf struct {
-	ApacheUrl3    string `koanf:"apache_url3"`
-	ApacheUrl4    string `koanf:"apache_url4"`
-	UrlPattern    string `koanf:"url_pattern"`
-	ShaUrlPattern string `koanf:"sha_url_pattern"`
+	ApacheUrl3    string                  `koanf:"apache_url3"`
+	ApacheUrl4    string                  `koanf:"apache_url4"`
+	UrlPattern    string                  `koanf:"url_pattern"`
+	ShaUrlPattern string                  `koanf:"sha_url_pattern"`
+	Mirrors       map[string]*MavenMirror `koanf:"mirrors"`
+	Mirror        string                  `koanf:"mirror"`
 	path          string
 }
 
@@ -36,4 +49,27 @@ func (that *MavenConf) Reset() {
 	that.ApacheUrl4 = "https://dlcdn.apache.org/maven/maven-4/"
 	that.UrlPattern = "%s%s/binaries/apache-maven-%s-bin.tar.gz"
 	that.ShaUrlPattern = "%s%s/binaries/apache-maven-%s-bin.tar.gz.sha512"
+	that.Mirrors = map[string]*MavenMirror{
+		"central": {
+			Id:       "central",
+			Url:      "https://repo.maven.apache.org/maven2/",
+			MirrorOf: "*",
+		},
+		"tencent": {
+			Id:       "nexus-tencentyun",
+			Url:      "http://mirrors.cloud.tencent.com/nexus/repository/maven-public/",
+			MirrorOf: "*",
+		},
+		"nju": {
+			Id:       "nju_mirror",
+			Url:      "https://repo.nju.edu.cn/repository/maven-public/",
+			MirrorOf: "*",
+		},
+		"aliyun": {
+			Id:       "aliyunmaven",
+			Url:      "https://maven.aliyun.com/repository/public",
+			MirrorOf: "*",
+		},
+	}
+	that.Mirror = "tencent"
 }
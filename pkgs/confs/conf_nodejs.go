@@ -8,10 +8,12 @@ import (
 )
 
 type NodejsConf struct {
-	CompilerUrl string   `koanf:"compiler_url"`
-	ReleaseUrl  string   `koanf:"release_url"`
-	ProxyUrls   []string `koanf:"proxy_urls"`
-	path        string
+	CompilerUrl  string   `koanf:"compiler_url"`
+	ReleaseUrl   string   `koanf:"release_url"`
+	ProxyUrls    []string `koanf:"proxy_urls"`
+	KeyringUrls  []string `koanf:"keyring_urls"`
+	SignatureUrl string   `koanf:"signature_url"`
+	path         string
 }
 
 func NewNodejsConf() (r *NodejsConf) {
@@ -39,4 +41,8 @@ func (that *NodejsConf) Reset() {
 		"https://mirrors.huaweicloud.com/repository/npm/",
 		"https://registry.npmjs.org/",
 	}
+	// keys.list contains fingerprints of the release team, keys are in keys/<fingerprint>.asc.
+	that.KeyringUrls = []string{"https://raw.githubusercontent.com/nodejs/release-keys/main/keys.list"}
+	// signed SHASUMS256.txt.asc is always downloaded from here, mirrors and release urls may not provide it.
+	that.SignatureUrl = "https://nodejs.org/dist/"
 }
@@ -15,6 +15,7 @@ type PyConf struct {
 	PypiProxies    []string `koanf:"pypi_proxies"`
 	PyBuildUrls    []string `koanf:"python_build_urls"`
 	PyBuildUrl     string   `koanf:"python_build_url"`
+	UseMirror      string   `koanf:"use_mirror"`
 	path           string
 }
 
@@ -14,6 +14,7 @@ type RustConf struct {
 	FileNameWin  string `koanf:"filename_win"`
 	DistServer   string `koanf:"RUSTUP_DIST_SERVER"`
 	UpdateRoot   string `koanf:"RUSTUP_UPDATE_ROOT"`
+	UseMirror    string `koanf:"use_mirror"`
 	path         string
 }
 
@@ -0,0 +1,21 @@
+package confs
+
+/*
+Global developer tools, reinstalled after switching sdk versions.
+*/
+type ToolsConf struct {
+	Go     []string `koanf:"go"`     // like golang.org/x/tools/gopls@v0.14.2, "@latest" by default.
+	Nodejs []string `koanf:"nodejs"` // like pnpm@8, typescript@5.
+	Rust   []string `koanf:"rust"`   // like cargo-watch, cargo-edit@0.12.2.
+}
+
+func NewToolsConf() (r *ToolsConf) {
+	r = &ToolsConf{}
+	return
+}
+
+func (that *ToolsConf) Reset() {
+	that.Go = []string{}
+	that.Nodejs = []string{}
+	that.Rust = []string{}
+}
@@ -0,0 +1,33 @@
+package confs
+
+import (
+	"os"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+type ZigConf struct {
+	IndexUrl string `koanf:"index_url"`
+	path     string
+}
+
+func NewZigConf() (r *ZigConf) {
+	r = &ZigConf{
+		path: ZigFilesDir,
+	}
+	r.setup()
+	return
+}
+
+func (that *ZigConf) setup() {
+	if ok, _ := utils.PathIsExist(that.path); !ok {
+		if err := os.MkdirAll(that.path, os.ModePerm); err != nil {
+			gprint.PrintError("%+v", err)
+		}
+	}
+}
+
+func (that *ZigConf) Reset() {
+	that.IndexUrl = "https://ziglang.org/download/index.json"
+}
@@ -15,6 +15,10 @@ gvc related
 */
 const (
 	GVCVersion = "v0.1.0"
+	// project-local version pinning file.
+	GVCVersionsFileName = ".gvc-versions"
+	// resolved versions for constraints in .gvc-versions.
+	GVCVersionsLockFileName = ".gvc-versions.lock"
 )
 
 var (
@@ -24,6 +28,12 @@ var (
 	GVCBackupDir        = filepath.Join(GVCDir, "backup")
 	GVConfigPath        = filepath.Join(GVCBackupDir, "gvc-config.json")
 	GVCBinTempDir       = filepath.Join(GVCDir, "bin_temp")
+	// shared download cache, indexed by checksum and url.
+	GVCCacheDir       = filepath.Join(GVCInstallDir, "cache")
+	GVCCacheBlobsDir  = filepath.Join(GVCCacheDir, "blobs")
+	GVCCacheIndexPath = filepath.Join(GVCCacheDir, "index.json")
+	// user-updatable openpgp keyring for release signatures.
+	GVCKeyringDir = filepath.Join(GVCDir, "keyrings")
 )
 
 func GetGVCWorkDir() string {
@@ -32,6 +42,10 @@ func GetGVCWorkDir() string {
 		content, _ := os.ReadFile(installPathConfig)
 		return string(content)
 	}
+	if !utils.CanPrompt() {
+		// Use the default dir and ask again next time.
+		return GVCDir
+	}
 	ipt := input.NewInput(input.WithPlaceholder(`set where to install packages; default: "$HomeDir/.gvc/"`), input.WithWidth(100))
 	ipt.Run()
 	d := ipt.Value()
@@ -313,52 +327,8 @@ var (
 	MavenSettingsFileDir = filepath.Join(MavenRoot, "conf")
 )
 
-var MavenSettingsPattern = `<?xml version="1.0" encoding="UTF-8"?>
-<settings xmlns="http://maven.apache.org/SETTINGS/1.2.0"
-          xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
-          xsi:schemaLocation="http://maven.apache.org/SETTINGS/1.2.0 https://maven.apache.org/xsd/settings-1.2.0.xsd">
-  <localRepository>%s</localRepository>
-  <pluginGroups>
-  </pluginGroups>
-
-  <proxies>
-  </proxies>
-
-  <servers>
-  </servers>
-
-  <mirrors>
-    <mirror>
-		<id>nexus-tencentyun</id>
-		<mirrorOf>*</mirrorOf>
-		<name>Nexus tencentyun</name>
-		<url>http://mirrors.cloud.tencent.com/nexus/repository/maven-public/</url>
-    </mirror>
-	<mirror>
-		<id>nju_mirror</id>
-		<mirrorOf>*</mirrorOf>
-		<name>Nexus nju</name>
-		<url>https://repo.nju.edu.cn/repository/maven-public/</url>
-    </mirror>
-	<mirror>
-		<id>aliyunmaven</id>
-		<mirrorOf>*</mirrorOf>
-		<name>Nexus aliyun</name>
-		<url>https://maven.aliyun.com/repository/public</url>
-	</mirror>
-	<mirror>
-		<id>alimaven</id>
-		<mirrorOf>central</mirrorOf>
-		<name>aliyun maven</name>
-		<url>https://maven.aliyun.com/repository/central</url>
-	</mirror>
-  </mirrors>
-
-  <profiles>
-  </profiles>
-</settings>`
-
-var MavenSettings = fmt.Sprintf(MavenSettingsPattern, JavaLocalRepoPath)
+// mirrors written to settings.xml by older versions of gvc, replaced when switching mirrors.
+var MavenLegacyMirrorIds = []string{"nexus-tencentyun", "nju_mirror", "aliyunmaven", "alimaven"}
 
 /*
 Rust related
@@ -558,6 +528,14 @@ var (
 	TypstRootDir  string = filepath.Join(TypstFilesDir, "typst")
 )
 
+/*
+Zig related
+*/
+var (
+	ZigFilesDir string = filepath.Join(GVCInstallDir, "zig_files")
+	ZigRootDir  string = filepath.Join(ZigFilesDir, "zig")
+)
+
 /*
 Chatgpt related
 */
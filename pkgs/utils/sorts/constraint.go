@@ -0,0 +1,115 @@
+package sorts
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+Version constraints like "1.21", "~1.20", "^1.20", ">=1.21 <1.22".
+*/
+var constraintRegexp = regexp.MustCompile(`^(>=|<=|>|<|=|~|\^)?v?(\d+(\.\d+)*)$`)
+
+type comparator struct {
+	Op      string
+	Version string
+}
+
+func (that *comparator) match(version string) bool {
+	c := CompareGoVersion(version, that.Version)
+	switch that.Op {
+	case ">=":
+		return c >= 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case "<":
+		return c < 0
+	default:
+		return c == 0
+	}
+}
+
+// upperBound returns the upper bound for "~1.20" and "^1.20".
+func upperBound(op, version string) string {
+	vList := strings.Split(version, ".")
+	major, _ := strconv.Atoi(vList[0])
+	if op == "^" && major > 0 || len(vList) < 2 {
+		return strconv.Itoa(major + 1)
+	}
+	minor, _ := strconv.Atoi(vList[1])
+	return vList[0] + "." + strconv.Itoa(minor+1)
+}
+
+func parseConstraint(constraint string) (cList []*comparator, ok bool) {
+	fields := strings.Fields(strings.ReplaceAll(constraint, ",", " "))
+	for idx := 0; idx < len(fields); idx++ {
+		field := fields[idx]
+		// allow ">= 1.21".
+		if constraintRegexp.FindStringSubmatch(field) == nil && idx+1 < len(fields) {
+			field += fields[idx+1]
+			idx++
+		}
+		sList := constraintRegexp.FindStringSubmatch(field)
+		if sList == nil {
+			return nil, false
+		}
+		op, version := sList[1], sList[2]
+		switch op {
+		case "~", "^":
+			cList = append(cList,
+				&comparator{Op: ">=", Version: version},
+				&comparator{Op: "<", Version: upperBound(op, version)},
+			)
+		case "", "=":
+			if len(fields) == 1 && strings.Count(version, ".") < 2 {
+				// "1.21" means the latest patch of 1.21.
+				cList = append(cList,
+					&comparator{Op: ">=", Version: version},
+					&comparator{Op: "<", Version: upperBound("~", version)},
+				)
+			} else {
+				cList = append(cList, &comparator{Op: "=", Version: version})
+			}
+		default:
+			cList = append(cList, &comparator{Op: op, Version: version})
+		}
+	}
+	return cList, len(cList) > 0
+}
+
+// IsVersionConstraint reports whether s is a constraint rather than a full version like 1.21.5.
+func IsVersionConstraint(s string) bool {
+	s = strings.TrimSpace(s)
+	if regexp.MustCompile(`^v?\d+\.\d+\.\d+$`).MatchString(s) {
+		return false
+	}
+	_, ok := parseConstraint(s)
+	return ok
+}
+
+// ResolveConstraint returns the greatest version matching the constraint, beta and rc versions are skipped.
+func ResolveConstraint(constraint string, versions []string) (r string, ok bool) {
+	cList, ok := parseConstraint(strings.TrimSpace(constraint))
+	if !ok {
+		return "", false
+	}
+	for _, v := range versions {
+		if gv := parseGoVersion(v); gv.Beta > 0 || gv.RC > 0 {
+			continue
+		}
+		matched := true
+		for _, c := range cList {
+			if !c.match(v) {
+				matched = false
+				break
+			}
+		}
+		if matched && (r == "" || CompareGoVersion(v, r) > 0) {
+			r = v
+		}
+	}
+	return r, r != ""
+}
@@ -0,0 +1,78 @@
+package sorts
+
+import "testing"
+
+func TestResolveConstraint(t *testing.T) {
+	versions := []string{
+		"1.19.13", "1.20", "1.20.1", "1.20.14", "1.21rc2", "1.21.0", "1.21.5", "1.22.0", "2.0.1", "2.1.0",
+	}
+	tests := []struct {
+		constraint string
+		want       string
+		ok         bool
+	}{
+		{"~1.20", "1.20.14", true},
+		{"~1.21.0", "1.21.5", true},
+		{"^1.20", "1.22.0", true},
+		{"^2", "2.1.0", true},
+		{"^0.3", "", false},
+		{">=1.21 <1.22", "1.21.5", true},
+		{">= 1.21 < 1.22", "1.21.5", true},
+		{">=1.20,<1.21", "1.20.14", true},
+		{">=1.20, <1.21", "1.20.14", true},
+		{">1.22.0", "2.1.0", true},
+		{"<=1.20.1", "1.20.1", true},
+		{"1.21", "1.21.5", true},
+		{"v1.21", "1.21.5", true},
+		{"=1.20.1", "1.20.1", true},
+		{"1.23", "", false},
+		{">=3", "", false},
+		{"abc", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ResolveConstraint(tt.constraint, versions)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ResolveConstraint(%q) = %q, %v, want %q, %v", tt.constraint, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestResolveConstraintMajorZero(t *testing.T) {
+	versions := []string{"0.3.1", "0.3.9", "0.4.0", "1.0.0"}
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"^0.3", "0.3.9"},
+		{"~0.3", "0.3.9"},
+		{"^0", "0.4.0"},
+	}
+	for _, tt := range tests {
+		if got, _ := ResolveConstraint(tt.constraint, versions); got != tt.want {
+			t.Errorf("ResolveConstraint(%q) = %q, want %q", tt.constraint, got, tt.want)
+		}
+	}
+}
+
+func TestIsVersionConstraint(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"1.21.5", false},
+		{"v20.11.0", false},
+		{"1.21", true},
+		{"20", true},
+		{"~1.20", true},
+		{"^20", true},
+		{">=1.21 <1.22", true},
+		{">=1.21, <1.22", true},
+		{"latest", false},
+		{"lts/iron", false},
+	}
+	for _, tt := range tests {
+		if got := IsVersionConstraint(tt.s); got != tt.want {
+			t.Errorf("IsVersionConstraint(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
@@ -54,39 +54,55 @@ func (that *gVersion) String() string {
 	return that.Origin
 }
 
+func parseGoVersion(v string) *gVersion {
+	var vresult []string
+	vs_ := &gVersion{Origin: v}
+	v = strings.TrimPrefix(v, "v")
+	if strings.Contains(v, "beta") {
+		result := strings.Split(v, "beta")
+		vresult = strings.Split(result[0], ".")
+		vs_.Beta, _ = strconv.Atoi(result[1])
+
+	} else if strings.Contains(v, "rc") {
+		result := strings.Split(v, "rc")
+		vresult = strings.Split(result[0], ".")
+		vs_.RC, _ = strconv.Atoi(result[1])
+	} else {
+		vresult = strings.Split(v, ".")
+
+	}
+	vs_.Major, _ = strconv.Atoi(vresult[0])
+	switch len(vresult) {
+	case 2:
+		vs_.Minor, _ = strconv.Atoi(vresult[1])
+	case 3:
+		vs_.Minor, _ = strconv.Atoi(vresult[1])
+		vs_.Patch, _ = strconv.Atoi(vresult[2])
+	}
+	return vs_
+}
+
 func SortGoVersion(vs []string) []string {
 	vList := []Item{}
-	var vresult []string
 	m := make(map[string]struct{}, 50)
 	for _, v := range vs {
 		if _, ok := m[v]; ok {
 			continue
 		}
 		m[v] = struct{}{}
-		vs_ := gVersion{}
-		if strings.Contains(v, "beta") {
-			result := strings.Split(v, "beta")
-			vresult = strings.Split(result[0], ".")
-			vs_.Beta, _ = strconv.Atoi(result[1])
-
-		} else if strings.Contains(v, "rc") {
-			result := strings.Split(v, "rc")
-			vresult = strings.Split(result[0], ".")
-			vs_.RC, _ = strconv.Atoi(result[1])
-		} else {
-			vresult = strings.Split(v, ".")
-
-		}
-		vs_.Major, _ = strconv.Atoi(vresult[0])
-		switch len(vresult) {
-		case 2:
-			vs_.Minor, _ = strconv.Atoi(vresult[1])
-		case 3:
-			vs_.Minor, _ = strconv.Atoi(vresult[1])
-			vs_.Patch, _ = strconv.Atoi(vresult[2])
-		}
-		vs_.Origin = v
-		vList = append(vList, &vs_)
+		vList = append(vList, parseGoVersion(v))
 	}
 	return QuickSort(vList)
 }
+
+// CompareGoVersion returns 1 if a is greater than b, -1 if a is less than b, otherwise 0.
+func CompareGoVersion(a, b string) int {
+	va, vb := parseGoVersion(a), parseGoVersion(b)
+	if va.Greater(vb) {
+		return 1
+	}
+	if vb.Greater(va) {
+		return -1
+	}
+	return 0
+}
@@ -50,21 +50,21 @@ func GetShell() (shell string) {
 	if runtime.GOOS == Windows {
 		return Win
 	}
-	s := os.Getenv("SHELL")
-	if strings.Contains(s, "zsh") {
+	s := filepath.Base(os.Getenv("SHELL"))
+	switch {
+	case strings.Contains(s, Fish):
+		return Fish
+	case strings.Contains(s, Zsh):
 		return Zsh
+	case strings.Contains(s, Bash):
+		return Bash
+	default:
+		return Sh
 	}
-	return Bash
 }
 
 func GetShellRcFile() (rc string) {
-	shell := GetShell()
-	switch shell {
-	case Zsh:
-		rc = filepath.Join(GetHomeDir(), ".zshrc")
-	case Bash:
-		rc = filepath.Join(GetHomeDir(), ".bashrc")
-	default:
+	if rc = ShellRcFilePath(GetShell()); rc == "" {
 		rc = Win
 	}
 	return
@@ -212,14 +212,8 @@ func GetExt(filename string) (ext string) {
 	return
 }
 
-func CheckFile(fpath, cType, cSum string) (r bool) {
-	f, err := os.Open(fpath)
-	if err != nil {
-		gprint.PrintError(fmt.Sprintf("Open file failed: %+v", err))
-		return false
-	}
-	defer f.Close()
-
+// HashFile returns the hex checksum of a file, cType can be sha256, sha1 or sha512.
+func HashFile(fpath, cType string) (string, error) {
 	var h hash.Hash
 	switch strings.ToLower(cType) {
 	case "sha256":
@@ -229,16 +223,29 @@ func CheckFile(fpath, cType, cSum string) (r bool) {
 	case "sha512":
 		h = sha512.New()
 	default:
-		gprint.PrintError(fmt.Sprintf("[Crypto] %s is not supported.", cType))
-		return
+		return "", fmt.Errorf("[Crypto] %s is not supported", cType)
 	}
 
+	f, err := os.Open(fpath)
+	if err != nil {
+		return "", fmt.Errorf("open file failed: %+v", err)
+	}
+	defer f.Close()
+
 	if _, err = io.Copy(h, f); err != nil {
-		gprint.PrintError(fmt.Sprintf("Copy file failed: %+v", err))
+		return "", fmt.Errorf("copy file failed: %+v", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func CheckFile(fpath, cType, cSum string) (r bool) {
+	sum, err := HashFile(fpath, cType)
+	if err != nil {
+		gprint.PrintError("%+v", err)
 		return
 	}
 
-	if cSum != hex.EncodeToString(h.Sum(nil)) {
+	if cSum != sum {
 		gprint.PrintError("Checksum failed.")
 		return
 	}
@@ -246,6 +253,27 @@ func CheckFile(fpath, cType, cSum string) (r bool) {
 	return true
 }
 
+// InsecureSkipVerify is set by --insecure-skip-verify.
+var InsecureSkipVerify bool
+
+// VerifyFile checks a downloaded file against its published checksum,
+// and fails closed when the checksum is missing, unless InsecureSkipVerify is set.
+func VerifyFile(fpath, cType, cSum string) bool {
+	if InsecureSkipVerify {
+		gprint.PrintWarning(fmt.Sprintf("Checksum verification skipped for %s.", filepath.Base(fpath)))
+		return true
+	}
+	if cSum == "" {
+		gprint.PrintError(fmt.Sprintf("No published checksum found for %s, refused to install it. Use --insecure-skip-verify to skip verification.", filepath.Base(fpath)))
+		return false
+	}
+	if !CheckFile(fpath, cType, cSum) {
+		gprint.PrintError(fmt.Sprintf("%s does not match the published %s checksum, refused to install it.", filepath.Base(fpath), strings.ToLower(cType)))
+		return false
+	}
+	return true
+}
+
 func JoinUnixFilePath(pathList ...string) (r string) {
 	newList := []string{}
 	for _, p := range pathList {
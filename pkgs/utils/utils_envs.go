@@ -44,6 +44,7 @@ const (
 	SUB_LUA     = "lua"
 	SUB_JULIA   = "julia"
 	SUB_TYPST   = "typst"
+	SUB_ZIG     = "zig"
 	SUB_VCPKG   = "vcpkg"
 	SUB_PROTOC  = "protoc"
 )
@@ -165,6 +166,11 @@ Typst Envs
 */
 var TypstEnv string = `export PATH="%s:$PATH"`
 
+/*
+Zig Envs
+*/
+var ZigEnv string = `export PATH="%s:$PATH"`
+
 /*
 VCPKG Envs
 */
@@ -199,28 +205,13 @@ func NewEnvsHandler() (e *EnvsHandler) {
 }
 
 func getShellTypeForUnix() (st string) {
-	s := os.Getenv("SHELL")
-	if strings.Contains(s, Zsh) {
-		st = Zsh
-	} else if strings.Contains(s, Bash) {
-		st = Bash
-	} else {
-		gprint.PrintError("Please use zsh or bash.")
-		os.Exit(1)
-	}
-	return
+	return GetShell()
 }
 
 func (that *EnvsHandler) getRcFilePath() {
-	switch that.shellName {
-	case Zsh:
-		that.rcFilePath = filepath.Join(GetHomeDir(), ".zshrc")
-	case Bash:
-		that.rcFilePath = filepath.Join(GetHomeDir(), ".bashrc")
-	case PowerShell:
-		that.rcFilePath = ""
-	default:
-		that.rcFilePath = ""
+	that.rcFilePath = ShellRcFilePath(that.shellName)
+	if that.rcFilePath != "" {
+		MakeDirs(filepath.Dir(that.rcFilePath))
 	}
 }
 
@@ -272,6 +263,7 @@ func (that *EnvsHandler) replaceBlockContent(start, end, value string) {
 }
 
 func (that *EnvsHandler) UpdateSub(subname, value string) {
+	value = RenderEnvs(that.shellName, value)
 	value = strings.ReplaceAll(value, GetHomeDir(), `$HOME`)
 	sub_start := fmt.Sprintf(SUB_BLOCK_START, subname)
 	sub_end := fmt.Sprintf(SUB_BLOCK_END, subname)
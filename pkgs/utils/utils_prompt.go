@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	NonInteractiveEnvName = "GVC_NON_INTERACTIVE"
+)
+
+/*
+Answers for interactive prompts, mostly set by global flags.
+*/
+type PromptAnswers struct {
+	AssumeYes      bool
+	NonInteractive bool
+	Source         string
+	Mirror         string
+	Compress       string
+	Targets        []string
+	Parallel       int
+}
+
+var Answers = &PromptAnswers{}
+
+func StdinIsTerminal() bool {
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// CanPrompt reports whether selectors and confirms may be shown.
+func CanPrompt() bool {
+	if Answers.AssumeYes || Answers.NonInteractive {
+		return false
+	}
+	if v, ok := ParseYesNo(os.Getenv(NonInteractiveEnvName)); ok && v {
+		return false
+	}
+	return StdinIsTerminal()
+}
+
+// ParseYesNo parses answers like "yes", "no", "true", "off".
+func ParseYesNo(s string) (value, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "y", "yes", "true", "on", "1":
+		return true, true
+	case "n", "no", "false", "off", "0":
+		return false, true
+	default:
+		return false, false
+	}
+}
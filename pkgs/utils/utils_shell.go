@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	Fish string = "fish"
+	Sh   string = "sh"
+)
+
+// ShellRcFilePath returns the file where gvc writes envs for the shell.
+func ShellRcFilePath(shell string) string {
+	switch shell {
+	case Zsh:
+		return filepath.Join(GetHomeDir(), ".zshrc")
+	case Bash:
+		return filepath.Join(GetHomeDir(), ".bashrc")
+	case Fish:
+		return filepath.Join(GetHomeDir(), ".config", "fish", "conf.d", "gvc.fish")
+	case Sh:
+		return filepath.Join(GetHomeDir(), ".profile")
+	default:
+		return ""
+	}
+}
+
+// QuoteForShell quotes a value with single quotes for the shell.
+func QuoteForShell(shell, value string) string {
+	if shell == Fish {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+		return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// ExportLine renders a line that exports an env for the shell.
+func ExportLine(shell, key, value string) string {
+	if shell == Fish {
+		if key == "PATH" {
+			pList := []string{}
+			for _, p := range strings.Split(value, ":") {
+				if p != "" {
+					pList = append(pList, QuoteForShell(shell, p))
+				}
+			}
+			return fmt.Sprintf("set -gx PATH %s;", strings.Join(pList, " "))
+		}
+		return fmt.Sprintf("set -gx %s %s;", key, QuoteForShell(shell, value))
+	}
+	return fmt.Sprintf("export %s=%s;", key, QuoteForShell(shell, value))
+}
+
+// UnsetLine renders a line that removes an env for the shell.
+func UnsetLine(shell, key string) string {
+	if shell == Fish {
+		return fmt.Sprintf("set -e %s;", key)
+	}
+	return fmt.Sprintf("unset %s;", key)
+}
+
+var exportRegexp = regexp.MustCompile(`^export\s+(\w+)=(.*)$`)
+
+// RenderEnvs renders env templates written in POSIX sh syntax for the shell.
+func RenderEnvs(shell, content string) string {
+	if shell != Fish {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	for idx, line := range lines {
+		sList := exportRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if len(sList) != 3 {
+			continue
+		}
+		key := sList[1]
+		value := sList[2]
+		if len(value) > 1 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+			value = value[1 : len(value)-1]
+		}
+		if key != "PATH" {
+			lines[idx] = fmt.Sprintf(`set -gx %s "%s"`, key, value)
+			continue
+		}
+		pList := []string{}
+		for _, p := range strings.Split(value, ":") {
+			if p == "$PATH" {
+				pList = append(pList, p)
+			} else if p != "" {
+				pList = append(pList, fmt.Sprintf(`"%s"`, p))
+			}
+		}
+		lines[idx] = fmt.Sprintf("set -gx PATH %s", strings.Join(pList, " "))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -10,7 +10,6 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/moqsien/goutils/pkgs/gtea/confirm"
 	"github.com/moqsien/goutils/pkgs/gtea/gprint"
 	"github.com/moqsien/goutils/pkgs/request"
 	config "github.com/moqsien/gvc/pkgs/confs"
@@ -81,16 +80,11 @@ func (that *Self) Install() {
 }
 
 func (that *Self) Uninstall() {
-	cfm := confirm.NewConfirm(confirm.WithTitle("To remove gvc?"))
-	cfm.Run()
-
-	if cfm.Result() {
+	if confirmOrNot("To remove gvc?", "", "") {
 		that.env.RemoveSubs()
 
-		cfmDAV := confirm.NewConfirm(confirm.WithTitle("Save config files to WebDAV before removing gvc?"))
-		cfmDAV.Run()
-
-		if cfmDAV.Result() {
+		// WebDAV account may need to be set interactively.
+		if utils.CanPrompt() && confirmOrNot("Save config files to WebDAV before removing gvc?", "", "") {
 			dav := NewGVCWebdav()
 			dav.GatherAndPushSettings()
 		}
@@ -148,9 +142,7 @@ func (that *Self) CheckLatestVersion(currentVersion string) {
 		gprint.PrintInfo(fmt.Sprintf("Current version: %s is already the latest.", currentVersion))
 		return
 	}
-	cfm := confirm.NewConfirm(confirm.WithTitle("To download the latest version for GVC or not?"))
-	cfm.Run()
-	if cfm.Result() {
+	if confirmOrNot("To download the latest version for GVC or not?", "", "") {
 		that.download()
 	}
 }
@@ -160,8 +152,7 @@ func (that *Self) download() {
 	if dUrl != "" {
 		fPath := filepath.Join(config.GVCBinTempDir, "gvc.zip")
 		fetcher := request.NewFetcher()
-		dUrl = that.Conf.GVCProxy.WrapUrl(dUrl)
-		fetcher.SetUrl(dUrl)
+		fetcher.SetUrl(that.Conf.GVCProxy.WrapUrl(dUrl))
 		fetcher.Timeout = 20 * time.Minute
 		temBinPath := filepath.Join(config.GVCBinTempDir, "gvc")
 		if runtime.GOOS == utils.Windows {
@@ -170,7 +161,7 @@ func (that *Self) download() {
 		// remove old files before get a new one
 		os.RemoveAll(fPath)
 		os.RemoveAll(temBinPath)
-		if err := fetcher.DownloadAndDecompress(fPath, config.GVCBinTempDir, true); err != nil {
+		if err := downloadGithubAsset(fetcher, dUrl, fPath, config.GVCBinTempDir); err != nil {
 			gprint.PrintError("%+v", err)
 		} else {
 			gprint.PrintSuccess(config.GVCBinTempDir)
@@ -0,0 +1,353 @@
+package vctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+/*
+Adopt sdks installed by the system, like openjdk in /usr/lib/jvm, go in /usr/lib/go or nodejs from NodeSource.
+
+Adopted sdks are linked into the versions dirs, so they are used and removed like versions downloaded by gvc,
+removing them only removes the links.
+*/
+type SystemAdopter interface {
+	// finds sdk homes installed by the system.
+	scanSystem() []string
+	// reads the version of an sdk home, returns the name in the versions dir.
+	inspectSystem(home string) (name string, err error)
+	versionsDir() string
+	// links an sdk home into the versions dir.
+	adoptSystem(home, name string) error
+}
+
+type AdoptedSDK struct {
+	Name    string `json:"name"`
+	Home    string `json:"home"`
+	Managed bool   `json:"managed"`
+}
+
+// globDirs expands glob patterns of dirs, missing dirs are ignored.
+func globDirs(patterns ...string) (dList []string) {
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(pattern)
+		for _, m := range matches {
+			if ok, _ := isDir(m); ok {
+				dList = append(dList, m)
+			}
+		}
+	}
+	return
+}
+
+// lookBinary finds the real path of a binary in PATH, like /usr/lib/go/bin/go for /usr/bin/go.
+func lookBinary(name string) string {
+	bin, err := exec.LookPath(name)
+	if err != nil {
+		return ""
+	}
+	if bin, err = filepath.EvalSymlinks(bin); err != nil {
+		return ""
+	}
+	return bin
+}
+
+// isGVCPath reports whether a real path is in GVCDir, sdks installed by gvc are not adopted.
+func isGVCPath(p string) bool {
+	gvcDir, err := filepath.EvalSymlinks(config.GVCDir)
+	if err != nil {
+		return false
+	}
+	return p == gvcDir || strings.HasPrefix(p, gvcDir+string(os.PathSeparator))
+}
+
+// AdoptSystemSDKs links system sdks into the versions dir of an sdk, homes are scanned if not specified.
+func AdoptSystemSDKs(sdkName string, homes []string, dryRun bool) (count int, err error) {
+	s := findSDK(sdkName)
+	if s == nil {
+		return 0, fmt.Errorf("unsupported sdk: %s, available: %s", sdkName, strings.Join(SDKNames(), ", "))
+	}
+	adopter, ok := s.NewManager().(SystemAdopter)
+	if !ok {
+		return 0, fmt.Errorf("adopting system installs is not supported for %s", s.Name)
+	}
+	scan := len(homes) == 0
+	if scan {
+		homes = adopter.scanSystem()
+	}
+
+	found := []*AdoptedSDK{}
+	seen := map[string]bool{}
+	failed := 0
+	for _, home := range homes {
+		realHome, e := filepath.EvalSymlinks(home)
+		if e == nil {
+			realHome, e = filepath.Abs(realHome)
+		}
+		if e != nil {
+			if !scan {
+				gprint.PrintError(fmt.Sprintf("Cannot find %s: %+v", home, e))
+				failed++
+			}
+			continue
+		}
+		if seen[realHome] || isGVCPath(realHome) {
+			continue
+		}
+		seen[realHome] = true
+		name, e := adopter.inspectSystem(realHome)
+		if e != nil {
+			if !scan {
+				gprint.PrintError(fmt.Sprintf("Cannot adopt %s: %+v", home, e))
+				failed++
+			}
+			continue
+		}
+		_, e = os.Lstat(filepath.Join(adopter.versionsDir(), name))
+		found = append(found, &AdoptedSDK{Name: name, Home: realHome, Managed: e == nil})
+	}
+
+	if dryRun {
+		printAdoptedSDKs(found)
+	}
+	for _, a := range found {
+		if a.Managed {
+			gprint.PrintInfo(fmt.Sprintf("%s %s is already managed, skipped %s.", s.Name, a.Name, a.Home))
+			continue
+		}
+		if dryRun {
+			continue
+		}
+		utils.MakeDirs(adopter.versionsDir())
+		if e := adopter.adoptSystem(a.Home, a.Name); e != nil {
+			gprint.PrintError(fmt.Sprintf("Adopt %s failed: %+v", a.Home, e))
+			failed++
+			continue
+		}
+		gprint.PrintSuccess(fmt.Sprintf("Adopted %s %s from %s.", s.Name, a.Name, a.Home))
+		count++
+	}
+	if scan && len(found) == 0 && !IsMachineOutput() {
+		gprint.PrintWarning(fmt.Sprintf("No system installs of %s found.", s.Name))
+	}
+	if failed > 0 {
+		err = fmt.Errorf("%d installs of %s failed to adopt", failed, s.Name)
+	}
+	return
+}
+
+func printAdoptedSDKs(found []*AdoptedSDK) {
+	if OutputFormat == OutputJSON {
+		content, _ := json.MarshalIndent(found, "", "  ")
+		fmt.Println(string(content))
+		return
+	}
+	for _, a := range found {
+		status := "new"
+		if a.Managed {
+			status = "managed"
+		}
+		if OutputFormat == OutputPlain {
+			fmt.Println(strings.Join([]string{a.Name, a.Home, status}, "\t"))
+			continue
+		}
+		gprint.Cyan("%-24s%s  (%s)", a.Name, a.Home, status)
+	}
+}
+
+/*
+Go
+*/
+func (that *GoVersion) scanSystem() (homes []string) {
+	switch runtime.GOOS {
+	case utils.Windows:
+		homes = globDirs(`C:\Program Files\Go`)
+	case utils.MacOS:
+		homes = globDirs("/usr/local/go", "/opt/homebrew/opt/go/libexec", "/usr/local/opt/go/libexec")
+	default:
+		homes = globDirs("/usr/lib/go", "/usr/lib/go-*", "/usr/lib/golang", "/usr/local/go", "/snap/go/current")
+	}
+	// installed by golang.org/dl.
+	homes = append(homes, globDirs(filepath.Join(utils.GetHomeDir(), "sdk", "go*"))...)
+	if goRoot := os.Getenv("GOROOT"); goRoot != "" {
+		homes = append(homes, goRoot)
+	}
+	if bin := lookBinary("go"); bin != "" {
+		homes = append(homes, filepath.Dir(filepath.Dir(bin)))
+	}
+	return
+}
+
+func (that *GoVersion) inspectSystem(home string) (name string, err error) {
+	if !isFile(filepath.Join(home, "bin", withExe("go"))) {
+		return "", fmt.Errorf("cannot find bin/go")
+	}
+	content, err := os.ReadFile(filepath.Join(home, "VERSION"))
+	if err != nil {
+		return "", err
+	}
+	// the first line is like go1.21.5.
+	version := strings.TrimSpace(strings.Split(string(content), "\n")[0])
+	if !strings.HasPrefix(version, "go") {
+		return "", fmt.Errorf("unknown go version: %s", version)
+	}
+	return strings.TrimPrefix(version, "go"), nil
+}
+
+func (that *GoVersion) versionsDir() string {
+	return config.GoUnTarFilesPath
+}
+
+// adoptSystem links the go root as <version>/go, the same layout as unarchived go versions.
+func (that *GoVersion) adoptSystem(home, name string) error {
+	vDir := filepath.Join(config.GoUnTarFilesPath, name)
+	if err := os.MkdirAll(vDir, os.ModePerm); err != nil {
+		return err
+	}
+	if err := utils.MkSymLink(home, filepath.Join(vDir, "go")); err != nil {
+		os.RemoveAll(vDir)
+		return err
+	}
+	return nil
+}
+
+/*
+Java
+*/
+func (that *JDKVersion) scanSystem() (homes []string) {
+	switch runtime.GOOS {
+	case utils.Windows:
+		homes = globDirs(
+			`C:\Program Files\Java\*`,
+			`C:\Program Files\Eclipse Adoptium\*`,
+			`C:\Program Files\Microsoft\jdk-*`,
+			`C:\Program Files\Zulu\*`,
+			`C:\Program Files\Amazon Corretto\*`,
+		)
+	case utils.MacOS:
+		homes = globDirs(
+			"/Library/Java/JavaVirtualMachines/*/Contents/Home",
+			"/opt/homebrew/opt/openjdk*/libexec/openjdk.jdk/Contents/Home",
+			"/usr/local/opt/openjdk*/libexec/openjdk.jdk/Contents/Home",
+		)
+	default:
+		homes = globDirs("/usr/lib/jvm/*", "/usr/lib64/jvm/*", "/usr/java/*")
+	}
+	if javaHome := os.Getenv("JAVA_HOME"); javaHome != "" {
+		homes = append(homes, javaHome)
+	}
+	if bin := lookBinary("javac"); bin != "" {
+		homes = append(homes, filepath.Dir(filepath.Dir(bin)))
+	}
+	return
+}
+
+// inspectSystem reads JAVA_VERSION in the release file, jres without javac are not adopted.
+func (that *JDKVersion) inspectSystem(home string) (name string, err error) {
+	if !isFile(filepath.Join(home, "bin", withExe("javac"))) {
+		return "", fmt.Errorf("cannot find bin/javac")
+	}
+	content, err := os.ReadFile(filepath.Join(home, "release"))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if !strings.HasPrefix(line, "JAVA_VERSION=") {
+			continue
+		}
+		version := strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "JAVA_VERSION=")), `"`)
+		// 1.8.0_392 -> 8.0.392
+		version = strings.ReplaceAll(strings.TrimPrefix(version, "1."), "_", ".")
+		if version != "" {
+			return vendorDirName(jdkSystemVendor, version), nil
+		}
+	}
+	return "", fmt.Errorf("cannot find JAVA_VERSION in %s", filepath.Join(home, "release"))
+}
+
+func (that *JDKVersion) versionsDir() string {
+	return config.JavaUnTarFilesPath
+}
+
+func (that *JDKVersion) adoptSystem(home, name string) error {
+	return utils.MkSymLink(home, filepath.Join(config.JavaUnTarFilesPath, name))
+}
+
+/*
+Nodejs
+*/
+func (that *NodeVersion) scanSystem() (homes []string) {
+	if runtime.GOOS == utils.Windows {
+		homes = globDirs(`C:\Program Files\nodejs`)
+	} else {
+		homes = globDirs("/usr/local/lib/nodejs/*", filepath.Join(utils.GetHomeDir(), ".nvm", "versions", "node", "*"))
+		for _, bin := range []string{"/usr/bin/node", "/usr/local/bin/node", "/opt/homebrew/bin/node"} {
+			if isFile(bin) {
+				homes = append(homes, filepath.Dir(filepath.Dir(bin)))
+			}
+		}
+	}
+	if bin := lookBinary("node"); bin != "" {
+		// node.exe is in the home dir on windows.
+		if home := filepath.Dir(bin); runtime.GOOS == utils.Windows {
+			homes = append(homes, home)
+		} else {
+			homes = append(homes, filepath.Dir(home))
+		}
+	}
+	return
+}
+
+func nodeBinary(home string) string {
+	if runtime.GOOS == utils.Windows {
+		return filepath.Join(home, "node.exe")
+	}
+	return filepath.Join(home, "bin", "node")
+}
+
+func (that *NodeVersion) inspectSystem(home string) (name string, err error) {
+	if !isFile(nodeBinary(home)) {
+		return "", fmt.Errorf("cannot find %s", nodeBinary(home))
+	}
+	out, err := exec.Command(nodeBinary(home), "--version").Output()
+	if err != nil {
+		return "", err
+	}
+	return "v" + strings.TrimPrefix(strings.TrimSpace(string(out)), "v"), nil
+}
+
+func (that *NodeVersion) versionsDir() string {
+	return config.NodejsUntarFiles
+}
+
+// adoptSystem links binaries into <version>/bin, for homes like /usr which are shared with other programs.
+func (that *NodeVersion) adoptSystem(home, name string) error {
+	vDir := filepath.Join(config.NodejsUntarFiles, name)
+	if runtime.GOOS == utils.Windows {
+		return utils.MkSymLink(home, vDir)
+	}
+	binDir := filepath.Join(vDir, "bin")
+	if err := os.MkdirAll(binDir, os.ModePerm); err != nil {
+		return err
+	}
+	for _, bin := range []string{"node", "npm", "npx", "corepack"} {
+		src := filepath.Join(home, "bin", bin)
+		if _, err := os.Lstat(src); err != nil {
+			continue
+		}
+		if err := utils.MkSymLink(src, filepath.Join(binDir, bin)); err != nil {
+			os.RemoveAll(vDir)
+			return err
+		}
+	}
+	return nil
+}
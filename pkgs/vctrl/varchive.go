@@ -0,0 +1,121 @@
+package vctrl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+/*
+Install sdks from local archive files, for machines without internet access.
+*/
+type ArchiveInstaller interface {
+	// unarchives a local archive file as InstallVersion does after download, returns the sdk home dir.
+	InstallArchive(version, archive string) string
+}
+
+var (
+	goArchiveRegexp      = regexp.MustCompile(`go(\d+\.\d+(\.\d+)?((rc|beta)\d+)?)`)
+	archiveVersionRegexp = regexp.MustCompile(`\d+(\.\d+)+`)
+)
+
+// versionFromFileName guesses the version from archive names like go1.21.5.linux-amd64.tar.gz.
+func versionFromFileName(name, fileName string) string {
+	switch name {
+	case "go":
+		if sList := goArchiveRegexp.FindStringSubmatch(fileName); len(sList) > 1 {
+			return sList[1]
+		}
+		return ""
+	case "java":
+		if v := numVersionRegexp.FindString(fileName); v != "" {
+			return normalizeVersion("java", v)
+		}
+		return ""
+	}
+	return archiveVersionRegexp.FindString(fileName)
+}
+
+var checksumTypes = map[int]string{
+	40:  "sha1",
+	64:  "sha256",
+	128: "sha512",
+}
+
+// parseChecksum parses checksums like "sha256:<hex>" or "<hex>", the type is guessed from the length.
+func parseChecksum(s string) (cType, cSum string, err error) {
+	s = strings.TrimSpace(s)
+	if sList := strings.SplitN(s, ":", 2); len(sList) == 2 {
+		return strings.ToLower(sList[0]), strings.ToLower(sList[1]), nil
+	}
+	cSum = strings.ToLower(s)
+	if cType = checksumTypes[len(cSum)]; cType == "" {
+		err = fmt.Errorf("unknown checksum type: %s", s)
+	}
+	return
+}
+
+// sidecarChecksum reads checksums from files like <archive>.sha256, in the format of sha256sum.
+func sidecarChecksum(archive string) (cType, cSum string) {
+	for _, suffix := range []string{".sha256", ".sha256sum", ".sha512", ".sha512sum", ".sha1", ".sha1sum"} {
+		content, err := os.ReadFile(archive + suffix)
+		if err != nil {
+			continue
+		}
+		if fields := strings.Fields(string(content)); len(fields) > 0 {
+			return strings.TrimSuffix(strings.TrimPrefix(suffix, "."), "sum"), strings.ToLower(fields[0])
+		}
+	}
+	return
+}
+
+// InstallFromFile checks a local archive with the given checksum or a sidecar checksum file,
+// then unarchives it into the versions dir. Version is guessed from the file name if empty.
+func InstallFromFile(name, version, archive, checksum string) (home string, err error) {
+	s := findSDK(name)
+	if s == nil {
+		return "", fmt.Errorf("unsupported sdk: %s, available: %s", name, strings.Join(SDKNames(), ", "))
+	}
+	installer, ok := s.NewManager().(ArchiveInstaller)
+	if !ok {
+		return "", fmt.Errorf("installing from files is not supported for %s", s.Name)
+	}
+	if archive, err = filepath.Abs(archive); err != nil {
+		return
+	}
+	if !isFile(archive) {
+		return "", fmt.Errorf("cannot find file: %s", archive)
+	}
+	if version == "" {
+		if version = versionFromFileName(s.Name, filepath.Base(archive)); version == "" {
+			return "", fmt.Errorf("cannot guess version from %s, please specify one", filepath.Base(archive))
+		}
+	}
+
+	var cType, cSum string
+	if checksum != "" {
+		if cType, cSum, err = parseChecksum(checksum); err != nil {
+			return
+		}
+	} else if cType, cSum = sidecarChecksum(archive); cSum == "" && !utils.InsecureSkipVerify {
+		return "", fmt.Errorf("no checksum found, use --checksum or put %s.sha256 next to the archive", filepath.Base(archive))
+	}
+	if !utils.VerifyFile(archive, cType, cSum) {
+		return "", fmt.Errorf("checksum of %s does not match", archive)
+	}
+	// later installs of the same archive will not download again.
+	if err := NewDownloadCache().add(archive, "", cType, cSum); err != nil {
+		gprint.PrintWarning(fmt.Sprintf("Cache file failed: %+v", err))
+	}
+
+	if home = installer.InstallArchive(version, archive); home == "" {
+		return "", fmt.Errorf("install %s %s from %s failed", s.Name, version, archive)
+	}
+	gprint.PrintSuccess(fmt.Sprintf("Installed %s %s to %s.", s.Name, version, home))
+	return
+}
@@ -44,16 +44,15 @@ func (that *Homebrew) SetEnv() {
 	itemList := selector.NewItemList()
 	itemList.Add("from mirrors.tuna.tsinghua.edu.cn", that.Conf.Homebrew.TsingHua)
 	itemList.Add("form mirrors.ustc.edu.cn", that.Conf.Homebrew.USTC)
-	sel := selector.NewSelector(
+	value := selectOne(
 		itemList,
-		selector.WidthEnableMulti(false),
+		utils.Answers.Source,
+		"source",
 		selector.WithEnbleInfinite(true),
 		selector.WithWidth(40),
 		selector.WithHeight(10),
 		selector.WithTitle("Choose a homebrew mirror"),
 	)
-	sel.Run()
-	value := sel.Value()[0]
 	envMap := value.(map[string]string)
 	if len(envMap) > 0 {
 		envars := fmt.Sprintf(utils.HOMEbrewEnv,
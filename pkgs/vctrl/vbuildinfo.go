@@ -0,0 +1,80 @@
+package vctrl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+)
+
+/*
+Version metadata injected into binaries by gvc go build, like:
+
+	"version_vars": {"tag": "main.GitTag", "hash": "main.GitHash", "time": "main.GitTime"}
+
+adds -X main.GitTag=<latest tag> -X main.GitHash=<commit> -X main.GitTime=<build time> to -ldflags.
+*/
+type GoBuildVersionVars struct {
+	Tag   string `koanf:"tag"`
+	Hash  string `koanf:"hash"`
+	Dirty string `koanf:"dirty"`
+	Time  string `koanf:"time"`
+}
+
+const buildTimeFormat = "2006-01-02@15:04:05"
+
+func (that *GoBuildVersionVars) isEmpty() bool {
+	return that.Tag == "" && that.Hash == "" && that.Dirty == "" && that.Time == ""
+}
+
+// ldflags returns -X flags for variables set in build.json.
+func (that *GoBuildVersionVars) ldflags() string {
+	tag := commandOutput("git", "describe", "--tags", "--abbrev=0")
+	hash := commandOutput("git", "rev-parse", "HEAD")
+	dirty := "false"
+	// untracked files, like the build output directory, do not make a build dirty.
+	if commandOutput("git", "status", "--porcelain", "--untracked-files=no") != "" {
+		dirty = "true"
+	}
+	gprint.PrintInfo(fmt.Sprintf("Version: tag=%s, commit=%s, dirty=%s.", tag, hash, dirty))
+
+	flags := []string{}
+	for _, v := range [][2]string{
+		{that.Tag, tag},
+		{that.Hash, hash},
+		{that.Dirty, dirty},
+		{that.Time, time.Now().Format(buildTimeFormat)},
+	} {
+		if v[0] == "" {
+			continue
+		}
+		if strings.ContainsAny(v[1], " \t") {
+			flags = append(flags, fmt.Sprintf("-X '%s=%s'", v[0], v[1]))
+		} else {
+			flags = append(flags, fmt.Sprintf("-X %s=%s", v[0], v[1]))
+		}
+	}
+	return strings.Join(flags, " ")
+}
+
+// injectVersionVars adds version flags to -ldflags in build args, "-s -w" is kept when -ldflags is absent.
+func injectVersionVars(buildArgs []string, vars *GoBuildVersionVars) []string {
+	if vars == nil || vars.isEmpty() {
+		return buildArgs
+	}
+	flags := vars.ldflags()
+	args := append([]string{}, buildArgs...)
+	for idx, a := range args {
+		if a == "-ldflags" && idx+1 < len(args) {
+			args[idx+1] = strings.TrimSpace(args[idx+1] + " " + flags)
+			return args
+		}
+		if strings.HasPrefix(a, "-ldflags=") {
+			value := strings.Trim(strings.TrimPrefix(a, "-ldflags="), `"'`)
+			args[idx] = "-ldflags=" + strings.TrimSpace(value+" "+flags)
+			return args
+		}
+	}
+	return append([]string{"-ldflags", "-s -w " + flags}, args...)
+}
@@ -0,0 +1,297 @@
+package vctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/goutils/pkgs/request"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+/*
+Shared download cache.
+
+Archives are stored as blobs/<sha256>, index.json records urls and checksums for each blob,
+so a repeated install never downloads twice, even if files in the downloads dirs are removed.
+*/
+type CacheEntry struct {
+	Sha256    string            `json:"sha256"`
+	FileName  string            `json:"file_name"`
+	Size      int64             `json:"size"`
+	Urls      []string          `json:"urls"`
+	Checksums map[string]string `json:"checksums,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UsedAt    time.Time         `json:"used_at"`
+}
+
+type DownloadCache struct {
+	Entries map[string]*CacheEntry `json:"entries"`
+}
+
+func NewDownloadCache() (dc *DownloadCache) {
+	dc = &DownloadCache{Entries: map[string]*CacheEntry{}}
+	if content, err := os.ReadFile(config.GVCCacheIndexPath); err == nil {
+		if err := json.Unmarshal(content, dc); err != nil {
+			gprint.PrintWarning(fmt.Sprintf("Cache index is broken: %+v", err))
+		}
+		if dc.Entries == nil {
+			dc.Entries = map[string]*CacheEntry{}
+		}
+	}
+	return
+}
+
+func (that *DownloadCache) save() error {
+	utils.MakeDirs(config.GVCCacheDir)
+	content, err := json.MarshalIndent(that, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(config.GVCCacheIndexPath, content, 0644)
+}
+
+func (that *DownloadCache) blobPath(e *CacheEntry) string {
+	return filepath.Join(config.GVCCacheBlobsDir, e.Sha256)
+}
+
+// find looks up an entry by checksum, or by url when no checksum is known.
+func (that *DownloadCache) find(dUrl, checkType, checksum string) *CacheEntry {
+	checkType = strings.ToLower(checkType)
+	for _, e := range that.Entries {
+		if !isFile(that.blobPath(e)) {
+			continue
+		}
+		if checksum != "" {
+			if e.Checksums[checkType] == checksum {
+				return e
+			}
+			continue
+		}
+		if containsString(e.Urls, dUrl) {
+			return e
+		}
+	}
+	return nil
+}
+
+// linkOrCopy hard links src to dst, and copies when links are not supported.
+func linkOrCopy(src, dst string) error {
+	os.RemoveAll(dst)
+	utils.MakeDirs(filepath.Dir(dst))
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	_, err := utils.CopyFile(src, dst)
+	return err
+}
+
+// add stores a downloaded file in the cache, files not matching the checksum are ignored.
+func (that *DownloadCache) add(fpath, dUrl, checkType, checksum string) error {
+	checkType = strings.ToLower(checkType)
+	sha, err := utils.HashFile(fpath, "sha256")
+	if err != nil {
+		return err
+	}
+	checksums := map[string]string{"sha256": sha}
+	if checksum != "" && checkType != "sha256" {
+		if checksums[checkType], err = utils.HashFile(fpath, checkType); err != nil {
+			return err
+		}
+	}
+	if checksum != "" && checksums[checkType] != checksum {
+		return fmt.Errorf("checksum of %s does not match, not cached", fpath)
+	}
+
+	e, ok := that.Entries[sha]
+	if !ok || !isFile(that.blobPath(e)) {
+		info, err := os.Stat(fpath)
+		if err != nil {
+			return err
+		}
+		e = &CacheEntry{
+			Sha256:    sha,
+			FileName:  filepath.Base(fpath),
+			Size:      info.Size(),
+			Checksums: map[string]string{},
+			CreatedAt: time.Now(),
+		}
+		if err := linkOrCopy(fpath, that.blobPath(e)); err != nil {
+			return err
+		}
+		that.Entries[sha] = e
+	}
+	for k, v := range checksums {
+		e.Checksums[k] = v
+	}
+	if dUrl != "" && !containsString(e.Urls, dUrl) {
+		e.Urls = append(e.Urls, dUrl)
+	}
+	e.UsedAt = time.Now()
+	return that.save()
+}
+
+// cachedDownload works like fetcher.GetAndSaveFile, but reuses files from the shared cache.
+// checkType and checksum are optional.
+func cachedDownload(fetcher *request.Fetcher, fpath, checkType, checksum string) (size int64) {
+	dc := NewDownloadCache()
+	if e := dc.find(fetcher.Url, checkType, checksum); e != nil {
+		if err := linkOrCopy(dc.blobPath(e), fpath); err == nil {
+			gprint.PrintInfo(fmt.Sprintf("Use cached file: %s", e.FileName))
+			if fetcher.Url != "" && !containsString(e.Urls, fetcher.Url) {
+				e.Urls = append(e.Urls, fetcher.Url)
+			}
+			e.UsedAt = time.Now()
+			dc.save()
+			return e.Size
+		}
+	}
+	if size = fetcher.GetAndSaveFile(fpath); size > 0 {
+		if err := dc.add(fpath, fetcher.Url, checkType, checksum); err != nil {
+			gprint.PrintWarning(fmt.Sprintf("Cache file failed: %+v", err))
+		}
+	}
+	return
+}
+
+func (that *DownloadCache) sortedEntries() (eList []*CacheEntry) {
+	for _, e := range that.Entries {
+		eList = append(eList, e)
+	}
+	sort.Slice(eList, func(i, j int) bool {
+		return eList[i].UsedAt.After(eList[j].UsedAt)
+	})
+	return
+}
+
+func formatSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	f := float64(size)
+	idx := 0
+	for f >= 1024 && idx < len(units)-1 {
+		f /= 1024
+		idx++
+	}
+	return fmt.Sprintf("%.1f%s", f, units[idx])
+}
+
+// ShowCache lists cached files, the most recently used first.
+func ShowCache() {
+	dc := NewDownloadCache()
+	eList := dc.sortedEntries()
+	if OutputFormat == OutputJSON {
+		if eList == nil {
+			eList = []*CacheEntry{}
+		}
+		content, _ := json.MarshalIndent(eList, "", "  ")
+		fmt.Println(string(content))
+		return
+	}
+	var total int64
+	for _, e := range eList {
+		total += e.Size
+		if OutputFormat == OutputPlain {
+			fmt.Println(strings.Join([]string{
+				e.Sha256, e.FileName, strconv.FormatInt(e.Size, 10),
+				e.UsedAt.Format(time.RFC3339), strings.Join(e.Urls, ","),
+			}, "\t"))
+			continue
+		}
+		gprint.Cyan("%s  %s  %s  last used: %s", e.Sha256[:12], formatSize(e.Size), e.FileName, e.UsedAt.Format("2006-01-02 15:04"))
+	}
+	if !IsMachineOutput() {
+		gprint.Yellow("%d files, %s in %s", len(eList), formatSize(total), config.GVCCacheDir)
+	}
+}
+
+// VerifyCache re-hashes cached files, broken files are removed from the cache.
+func VerifyCache() (broken int) {
+	dc := NewDownloadCache()
+	for _, e := range dc.sortedEntries() {
+		ok := true
+		for cType, cSum := range e.Checksums {
+			if sum, err := utils.HashFile(dc.blobPath(e), cType); err != nil || sum != cSum {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			gprint.PrintSuccess(fmt.Sprintf("%s  %s", e.Sha256[:12], e.FileName))
+			continue
+		}
+		broken++
+		gprint.PrintError(fmt.Sprintf("%s  %s is broken, removed.", e.Sha256[:12], e.FileName))
+		os.RemoveAll(dc.blobPath(e))
+		delete(dc.Entries, e.Sha256)
+	}
+	if err := dc.save(); err != nil {
+		gprint.PrintError("%+v", err)
+	}
+	return
+}
+
+// PruneCache removes files unused for olderThan, then the least recently used files until
+// the cache is not larger than maxSize. Zero values are ignored.
+func PruneCache(olderThan time.Duration, maxSize int64) {
+	dc := NewDownloadCache()
+	eList := dc.sortedEntries()
+	var total int64
+	removed := 0
+	for _, e := range eList {
+		expired := olderThan > 0 && time.Since(e.UsedAt) > olderThan
+		if !expired && (maxSize <= 0 || total+e.Size <= maxSize) {
+			total += e.Size
+			continue
+		}
+		os.RemoveAll(dc.blobPath(e))
+		delete(dc.Entries, e.Sha256)
+		removed++
+		gprint.PrintInfo(fmt.Sprintf("Removed %s", e.FileName))
+	}
+	if err := dc.save(); err != nil {
+		gprint.PrintError("%+v", err)
+		return
+	}
+	gprint.PrintSuccess(fmt.Sprintf("Removed %d files, %s left.", removed, formatSize(total)))
+}
+
+// ParseAge parses durations like "30d", "2w" or "12h".
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if strings.HasSuffix(s, suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSuffix(s, suffix), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration: %s", s)
+			}
+			return time.Duration(n * float64(unit)), nil
+		}
+	}
+	return time.ParseDuration(s)
+}
+
+// ParseSize parses sizes like "500MB", "2G" or "1024".
+func ParseSize(size string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(size))
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "IB"), "B")
+	unit := int64(1)
+	for idx, suffix := range []string{"K", "M", "G", "T"} {
+		if strings.HasSuffix(s, suffix) {
+			unit = 1 << (10 * (idx + 1))
+			s = strings.TrimSuffix(s, suffix)
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size: %s", size)
+	}
+	return int64(n * float64(unit)), nil
+}
@@ -12,7 +12,6 @@ import (
 	"time"
 
 	"github.com/mholt/archiver/v3"
-	"github.com/moqsien/goutils/pkgs/gtea/confirm"
 	"github.com/moqsien/goutils/pkgs/gtea/gprint"
 	"github.com/moqsien/goutils/pkgs/request"
 	config "github.com/moqsien/gvc/pkgs/confs"
@@ -104,15 +103,16 @@ func (that *Code) download() (r string) {
 			return
 		}
 		fpath := filepath.Join(config.CodeTarFileDir, fmt.Sprintf("%s-%s%s", key, that.Version, suffix))
-		cfm := confirm.NewConfirm(confirm.WithTitle("Use vscode.cdn.azure.cn to accelerate download or not?"))
-		cfm.Run()
-		if cfm.Result() {
+		useCdn := rememberAnswer(that.Conf, &that.Conf.Code.UseCdn, utils.Answers.Mirror)
+		if confirmOrNot("Use vscode.cdn.azure.cn to accelerate download or not?", useCdn, "mirror") {
 			that.fetcher.Url = strings.Replace(p.Url, that.Conf.Code.StableUrl, that.Conf.Code.CdnUrl, -1)
+		} else {
+			that.fetcher.Url = p.Url
 		}
 		that.fetcher.Timeout = 600 * time.Second
 		that.fetcher.SetThreadNum(8)
-		if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
-			if ok := utils.CheckFile(fpath, p.CheckType, p.CheckSum); ok {
+		if size := cachedDownload(that.fetcher, fpath, p.CheckType, p.CheckSum); size > 0 {
+			if ok := utils.VerifyFile(fpath, p.CheckType, p.CheckSum); ok {
 				r = fpath
 			} else {
 				os.RemoveAll(fpath)
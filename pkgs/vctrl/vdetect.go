@@ -0,0 +1,222 @@
+package vctrl
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	config "github.com/moqsien/gvc/pkgs/confs"
+)
+
+/*
+Version files used by other tools.
+*/
+type foreignVersionFile struct {
+	FileName string
+	Parse    func(fPath string) []*PinnedVersion
+}
+
+var foreignVersionFiles = []*foreignVersionFile{
+	{FileName: ".tool-versions", Parse: parseToolVersions},
+	{FileName: ".go-version", Parse: singleVersionParser("go")},
+	{FileName: "go.mod", Parse: parseGoMod},
+	{FileName: ".nvmrc", Parse: singleVersionParser("nodejs")},
+	{FileName: ".node-version", Parse: singleVersionParser("nodejs")},
+	{FileName: "package.json", Parse: parsePackageJson},
+	{FileName: ".python-version", Parse: singleVersionParser("python")},
+	{FileName: ".java-version", Parse: singleVersionParser("java")},
+}
+
+// asdf plugin names.
+var asdfNames = map[string]string{
+	"golang":  "go",
+	"go":      "go",
+	"nodejs":  "nodejs",
+	"python":  "python",
+	"java":    "java",
+	"maven":   "maven",
+	"gradle":  "gradle",
+	"flutter": "flutter",
+	"julia":   "julia",
+}
+
+var numVersionRegexp = regexp.MustCompile(`\d+(\.\d+)*`)
+
+// normalizeVersion turns versions written for other tools into gvc versions.
+func normalizeVersion(name, version string) string {
+	version = strings.TrimSpace(version)
+	switch name {
+	case "go":
+		version = strings.TrimPrefix(version, "go")
+		// since go1.21, the first release is named like 1.21.0.
+		if vList := strings.Split(version, "."); len(vList) == 2 {
+			if minor, err := strconv.Atoi(vList[1]); err == nil && vList[0] == "1" && minor >= 21 {
+				version += ".0"
+			}
+		}
+	case "nodejs":
+		version = normalizeNodeRange(version)
+	case "java":
+		// openjdk-17.0.2, temurin-17.0.8+7, 1.8 -> major version.
+		vList := strings.Split(numVersionRegexp.FindString(version), ".")
+		if len(vList) > 1 && vList[0] == "1" {
+			return vList[1]
+		}
+		return vList[0]
+	}
+	return version
+}
+
+// normalizeNodeRange converts semver ranges like "^18.17.0" or ">=18" to version prefixes.
+func normalizeNodeRange(version string) string {
+	version = strings.TrimSpace(strings.Split(version, "||")[0])
+	version = strings.Fields(version + " ")[0]
+	switch {
+	case strings.HasPrefix(version, "^"), strings.HasPrefix(version, ">"):
+		return strings.Split(numVersionRegexp.FindString(version), ".")[0]
+	case strings.HasPrefix(version, "~"):
+		vList := strings.Split(numVersionRegexp.FindString(version), ".")
+		if len(vList) > 2 {
+			vList = vList[:2]
+		}
+		return strings.Join(vList, ".")
+	}
+	return numVersionRegexp.FindString(version)
+}
+
+func readLines(fPath string) (r []string) {
+	f, err := os.Open(fPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			r = append(r, line)
+		}
+	}
+	return
+}
+
+func singleVersionParser(name string) func(fPath string) []*PinnedVersion {
+	return func(fPath string) []*PinnedVersion {
+		lines := readLines(fPath)
+		if len(lines) == 0 {
+			return nil
+		}
+		version := normalizeVersion(name, strings.Fields(lines[0])[0])
+		if version == "" {
+			return nil
+		}
+		return []*PinnedVersion{{Name: name, Version: version, File: fPath}}
+	}
+}
+
+func parseToolVersions(fPath string) (r []*PinnedVersion) {
+	for _, line := range readLines(fPath) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		name, ok := asdfNames[fields[0]]
+		if !ok || fields[1] == "system" || strings.Contains(fields[1], ":") {
+			continue
+		}
+		if version := normalizeVersion(name, fields[1]); version != "" {
+			r = append(r, &PinnedVersion{Name: name, Version: version, File: fPath})
+		}
+	}
+	return
+}
+
+// parseGoMod reads the toolchain directive, or the go directive.
+func parseGoMod(fPath string) []*PinnedVersion {
+	var goVersion, toolchain string
+	for _, line := range readLines(fPath) {
+		line = strings.Split(line, "//")[0]
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[0] {
+		case "go":
+			goVersion = fields[1]
+		case "toolchain":
+			if fields[1] != "default" {
+				toolchain = fields[1]
+			}
+		}
+	}
+	if toolchain != "" {
+		goVersion = toolchain
+	}
+	if goVersion == "" {
+		return nil
+	}
+	return []*PinnedVersion{{Name: "go", Version: normalizeVersion("go", goVersion), File: fPath}}
+}
+
+// parsePackageJson reads engines.node.
+func parsePackageJson(fPath string) []*PinnedVersion {
+	content, err := os.ReadFile(fPath)
+	if err != nil {
+		return nil
+	}
+	pkg := struct {
+		Engines map[string]string `json:"engines"`
+	}{}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil
+	}
+	version := normalizeVersion("nodejs", pkg.Engines["node"])
+	if version == "" {
+		return nil
+	}
+	return []*PinnedVersion{{Name: "nodejs", Version: version, File: fPath}}
+}
+
+// DetectVersions finds versions from .gvc-versions and version files of other tools,
+// from dir up to the root. Files in nearer dirs win.
+func DetectVersions(dir string) (r []*PinnedVersion, err error) {
+	dir, _ = filepath.Abs(dir)
+	found := map[string]struct{}{}
+	add := func(pList []*PinnedVersion) {
+		for _, p := range pList {
+			if _, ok := found[p.Name]; !ok {
+				found[p.Name] = struct{}{}
+				r = append(r, p)
+			}
+		}
+	}
+	for {
+		pinFile := filepath.Join(dir, config.GVCVersionsFileName)
+		if isFile(pinFile) {
+			pList, err := ParsePinFile(pinFile)
+			if err != nil {
+				return nil, err
+			}
+			applyLockFile(pList)
+			add(pList)
+		}
+		for _, vf := range foreignVersionFiles {
+			fPath := filepath.Join(dir, vf.FileName)
+			if isFile(fPath) {
+				add(vf.Parse(fPath))
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,182 @@
+package vctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/moqsien/goutils/pkgs/archiver"
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/goutils/pkgs/request"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+/*
+Published digests of github release assets.
+
+GitHub records a sha256 digest for each release asset, older releases may publish
+checksum files like <asset>.sha256 or SHA256SUMS instead.
+*/
+var githubAssetRegexp = regexp.MustCompile(`github\.com/([^/]+)/([^/]+)/releases/(latest/download|download/([^/]+))/([^/?#]+)`)
+
+var githubChecksumFiles = []string{"SHA256SUMS", "SHA256SUMS.txt", "sha256sums.txt", "checksums.txt", "shasum.txt"}
+
+type githubAsset struct {
+	Name        string `json:"name"`
+	Digest      string `json:"digest"`
+	DownloadUrl string `json:"browser_download_url"`
+}
+
+type githubRelease struct {
+	TagName string         `json:"tag_name"`
+	Assets  []*githubAsset `json:"assets"`
+}
+
+func getText(dUrl string) string {
+	fetcher := request.NewFetcher()
+	fetcher.Url = dUrl
+	fetcher.Timeout = 30 * time.Second
+	if resp := fetcher.Get(); resp != nil && resp.StatusCode() == 200 {
+		content, _ := io.ReadAll(resp.RawBody())
+		return string(content)
+	}
+	return ""
+}
+
+// getGithubText requests github directly, with GITHUB_TOKEN if it is set, which raises the rate limit of api.github.com.
+// The reverse proxy of gvc is only used when github is unreachable, with a warning,
+// because digests from the proxy are not published by github itself.
+func getGithubText(gUrl string) string {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+	fetcher := request.NewFetcher()
+	fetcher.Url = gUrl
+	fetcher.Timeout = 30 * time.Second
+	if token != "" {
+		fetcher.Headers = map[string]string{"Authorization": "Bearer " + token}
+	}
+	if resp := fetcher.Get(); resp != nil && resp.StatusCode() == 200 {
+		content, _ := io.ReadAll(resp.RawBody())
+		return string(content)
+	}
+	// tokens are never sent to proxies.
+	pUrl := config.New().GVCProxy.WrapUrl(gUrl)
+	if pUrl == gUrl {
+		return ""
+	}
+	content := getText(pUrl)
+	if content != "" {
+		gprint.PrintWarning(fmt.Sprintf("Cannot reach %s, fetched it through the gvc proxy, the checksum is not from github directly.", gUrl))
+	}
+	return content
+}
+
+// findChecksumLine finds the checksum of fileName in files like SHA256SUMS,
+// files containing only a checksum are also supported.
+func findChecksumLine(content, fileName string) string {
+	if fields := strings.Fields(content); len(fields) == 1 {
+		return fields[0]
+	}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && strings.TrimPrefix(fields[len(fields)-1], "*") == fileName {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// githubAssetDigest returns the published digest of a release asset, like:
+// https://github.com/neovim/neovim/releases/latest/download/nvim-linux64.tar.gz
+func githubAssetDigest(assetUrl string) (cType, cSum string) {
+	sList := githubAssetRegexp.FindStringSubmatch(assetUrl)
+	if len(sList) != 6 {
+		return
+	}
+	owner, repo, tag, name := sList[1], sList[2], sList[4], sList[5]
+	apiUrl := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", owner, repo)
+	if tag != "" {
+		apiUrl = fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", owner, repo, tag)
+	}
+	release := &githubRelease{}
+	if err := json.Unmarshal([]byte(getGithubText(apiUrl)), release); err != nil {
+		return
+	}
+	assets := map[string]*githubAsset{}
+	for _, a := range release.Assets {
+		assets[a.Name] = a
+	}
+	if a, ok := assets[name]; ok && a.Digest != "" {
+		if dList := strings.SplitN(a.Digest, ":", 2); len(dList) == 2 {
+			return dList[0], dList[1]
+		}
+	}
+	for _, suffix := range []string{".sha256", ".sha256sum", ".sha256.txt"} {
+		if a, ok := assets[name+suffix]; ok {
+			if cSum = findChecksumLine(getGithubText(a.DownloadUrl), name); cSum != "" {
+				return "sha256", cSum
+			}
+		}
+	}
+	for _, fName := range githubChecksumFiles {
+		if a, ok := assets[fName]; ok {
+			if cSum = findChecksumLine(getGithubText(a.DownloadUrl), name); cSum != "" {
+				return "sha256", cSum
+			}
+		}
+	}
+	return
+}
+
+// requireGithubDigest finds the digest of a release asset before downloading.
+// ok is false when no digest is published, unless verification is skipped.
+func requireGithubDigest(assetUrl string) (cType, cSum string, ok bool) {
+	if utils.InsecureSkipVerify {
+		gprint.PrintWarning("Checksum verification skipped.")
+		return "", "", true
+	}
+	if cType, cSum = githubAssetDigest(assetUrl); cSum == "" {
+		gprint.PrintError(fmt.Sprintf("No published checksum found for %s, refused to install it. Use --insecure-skip-verify to skip verification.", assetUrl))
+		return "", "", false
+	}
+	return cType, strings.ToLower(cSum), true
+}
+
+// downloadGithubAsset downloads a release asset to fpath, and unarchives it into dstDir after verification.
+func downloadGithubAsset(fetcher *request.Fetcher, assetUrl, fpath, dstDir string) error {
+	os.RemoveAll(fpath)
+	if size := fetcher.GetAndSaveFile(fpath, true); size <= 0 {
+		return fmt.Errorf("download %s failed", assetUrl)
+	}
+	if !verifyGithubAsset(fpath, assetUrl) {
+		os.RemoveAll(fpath)
+		return fmt.Errorf("verify %s failed", assetUrl)
+	}
+	a, err := archiver.NewArchiver(fpath, dstDir, false)
+	if err == nil {
+		_, err = a.UnArchive()
+	}
+	if err != nil {
+		os.RemoveAll(fpath)
+	}
+	return err
+}
+
+// verifyGithubAsset verifies a downloaded release asset.
+func verifyGithubAsset(fpath, assetUrl string) bool {
+	cType, cSum, ok := requireGithubDigest(assetUrl)
+	if !ok {
+		return false
+	}
+	if utils.InsecureSkipVerify {
+		return true
+	}
+	return utils.VerifyFile(fpath, cType, cSum)
+}
@@ -0,0 +1,72 @@
+package vctrl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+)
+
+/*
+Run commands under specific sdk versions, without switching globally.
+*/
+func prepareExecEnvs(specs []string) error {
+	binDirs := []string{}
+	for _, spec := range specs {
+		name, version := ParseSDKArg(spec)
+		s := findSDK(name)
+		if s == nil {
+			return fmt.Errorf("unsupported sdk: %s, available: %s", name, strings.Join(SDKNames(), ", "))
+		}
+		if version == "" {
+			return fmt.Errorf("please specify a version for %s, like %s@<version>", name, name)
+		}
+		var home string
+		if s.FindInstalled != nil {
+			home = s.FindInstalled(version)
+		}
+		if home == "" {
+			home = s.NewManager().InstallVersion(version)
+		}
+		if home == "" {
+			return fmt.Errorf("cannot install %s@%s", name, version)
+		}
+		if s.HomeEnv != "" {
+			os.Setenv(s.HomeEnv, home)
+		}
+		binDirs = append(binDirs, filepath.Join(home, "bin"))
+	}
+	binDirs = append(binDirs, os.Getenv("PATH"))
+	return os.Setenv("PATH", strings.Join(binDirs, string(os.PathListSeparator)))
+}
+
+// ExecWithVersions runs a command with sdk versions like "go@1.20.14" prepended to PATH,
+// and returns the exit code of the command.
+func ExecWithVersions(specs []string, args []string) int {
+	if len(args) == 0 {
+		gprint.PrintError("Please specify a command to run.")
+		return 1
+	}
+	if err := prepareExecEnvs(specs); err != nil {
+		gprint.PrintError("%+v", err)
+		return 1
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = os.Environ()
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		gprint.PrintError("%+v", err)
+		return 1
+	}
+	return 0
+}
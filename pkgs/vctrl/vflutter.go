@@ -72,17 +72,16 @@ func (that *FlutterVersion) ChooseSource() {
 		itemList := selector.NewItemList()
 		itemList.Add("from flutter-io.cn", that.Conf.Flutter.DefaultURLs)
 		itemList.Add("from googleapis.com", that.Conf.Flutter.OfficialURLs)
-		sel := selector.NewSelector(
+		source := rememberAnswer(that.Conf, &that.Conf.Flutter.DownloadSource, utils.Answers.Source)
+		value := selectOne(
 			itemList,
+			source,
+			"source",
 			selector.WithTitle("Choose download resource:"),
 			selector.WithEnbleInfinite(true),
-			selector.WidthEnableMulti(false),
 			selector.WithHeight(10),
 			selector.WithWidth(40),
 		)
-		sel.Run()
-
-		value := sel.Value()[0]
 		that.flutterConf = value.(map[string]string)
 	}
 }
@@ -157,8 +156,23 @@ func (that *FlutterVersion) ShowVersions() {
 		vList = append(vList, k)
 	}
 	res := sorts.SortGoVersion(vList)
-	fc := gprint.NewFadeColors(res)
-	fc.Println()
+	if !IsMachineOutput() {
+		fc := gprint.NewFadeColors(res)
+		fc.Println()
+		return
+	}
+	current := that.getCurrent()
+	infos := []*VersionInfo{}
+	for _, v := range res {
+		info := &VersionInfo{Version: v, OS: runtime.GOOS, Arch: runtime.GOARCH, Current: v == current}
+		if p := that.findPackage(v); p != nil {
+			info.Url, _ = url.JoinPath(that.baseUrl, p.Url)
+			info.Checksum = p.Checksum
+		}
+		info.Installed, _ = utils.PathIsExist(filepath.Join(config.FlutterUntarFilePath, v))
+		infos = append(infos, info)
+	}
+	PrintVersionInfos(infos)
 }
 
 func (that *FlutterVersion) findPackage(version string) *FlutterPackage {
@@ -183,15 +197,11 @@ func (that *FlutterVersion) download(version string) (r string) {
 		that.fetcher.Timeout = 100 * time.Minute
 		// that.fetcher.SetThreadNum(2)
 		fpath := filepath.Join(config.FlutterTarFilePath, p.FileName)
-		if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
-			if p.Checksum != "" {
-				if ok := utils.CheckFile(fpath, "sha256", p.Checksum); ok {
-					return fpath
-				} else {
-					os.RemoveAll(fpath)
-				}
-			} else {
+		if size := cachedDownload(that.fetcher, fpath, "sha256", p.Checksum); size > 0 {
+			if ok := utils.VerifyFile(fpath, "sha256", p.Checksum); ok {
 				return fpath
+			} else {
+				os.RemoveAll(fpath)
 			}
 		} else {
 			os.RemoveAll(fpath)
@@ -242,23 +252,53 @@ func (that *FlutterVersion) FixForFlutter() {
 	// }
 }
 
-func (that *FlutterVersion) UseVersion(version string) {
+// InstallVersion downloads and unarchives a version without switching to it.
+// Returns the FLUTTER_ROOT of the installed version.
+func (that *FlutterVersion) InstallVersion(version string) (flutterRoot string) {
+	return that.install(version, that.download)
+}
+
+// InstallArchive unarchives a local archive file as InstallVersion does after download.
+func (that *FlutterVersion) InstallArchive(version, archive string) (flutterRoot string) {
+	return that.install(version, func(string) string { return archive })
+}
+
+func (that *FlutterVersion) install(version string, getArchive func(version string) string) (flutterRoot string) {
+	untarfile := filepath.Join(config.FlutterUntarFilePath, version)
+	if ok, _ := utils.PathIsExist(untarfile); !ok {
+		if tarfile := getArchive(version); tarfile != "" {
+			if err := archiver.Unarchive(tarfile, untarfile); err != nil {
+				os.RemoveAll(untarfile)
+				gprint.PrintError(fmt.Sprintf("Unarchive failed: %+v", err))
+				return
+			}
+		}
+	}
+	// flutter archives always contain a top level "flutter" dir.
+	flutterRoot = filepath.Join(untarfile, "flutter")
+	if ok, _ := utils.PathIsExist(flutterRoot); !ok {
+		return ""
+	}
+	return
+}
+
+func (that *FlutterVersion) UseVersion(version string) bool {
 	current := that.getCurrent()
 	if version == current {
 		gprint.PrintSuccess(fmt.Sprintf("Use %s succeeded!", version))
-		return
+		return true
+	}
+	flutterRoot := that.InstallVersion(version)
+	if flutterRoot == "" {
+		gprint.PrintError(fmt.Sprintf("Use %s failed!", version))
+		return false
 	}
 	if ok, _ := utils.PathIsExist(config.FlutterRootDir); ok {
 		os.RemoveAll(config.FlutterRootDir)
 	}
-
-	untarfile := config.FlutterFilesDir
-	if tarfile := that.download(version); tarfile != "" {
-		if err := archiver.Unarchive(tarfile, untarfile); err != nil {
-			os.RemoveAll(untarfile)
-			gprint.PrintError(fmt.Sprintf("Unarchive failed: %+v", err))
-			return
-		}
+	if err := utils.MkSymLink(flutterRoot, config.FlutterRootDir); err != nil {
+		gprint.PrintError(fmt.Sprintf("Create link failed: %+v", err))
+		return false
 	}
 
 	if ok, _ := utils.PathIsExist(config.FlutterRootDir); ok {
@@ -270,9 +310,10 @@ func (that *FlutterVersion) UseVersion(version string) {
 			that.FixForFlutter()
 		}
 		gprint.PrintSuccess(fmt.Sprintf("Use %s succeeded!", version))
-	} else {
-		gprint.PrintError(fmt.Sprintf("Use %s failed!", version))
+		return true
 	}
+	gprint.PrintError(fmt.Sprintf("Use %s failed!", version))
+	return false
 }
 
 func (that *FlutterVersion) getCurrent() string {
@@ -284,6 +325,7 @@ func (that *FlutterVersion) ShowInstalled() {
 	current := that.getCurrent()
 	dList, _ := os.ReadDir(config.FlutterTarFilePath)
 	reg := regexp.MustCompile(`(\d+\.\d+\.\d+)`)
+	infos := []*VersionInfo{}
 	for _, d := range dList {
 
 		if !d.IsDir() {
@@ -296,14 +338,16 @@ func (that *FlutterVersion) ShowInstalled() {
 			if versionName == "" {
 				continue
 			}
-			switch versionName {
-			case current:
-				gprint.Yellow("%s <Current>", versionName)
-			default:
-				gprint.Cyan(versionName)
-			}
+			infos = append(infos, &VersionInfo{
+				Version:   versionName,
+				OS:        runtime.GOOS,
+				Arch:      runtime.GOARCH,
+				Installed: true,
+				Current:   versionName == current,
+			})
 		}
 	}
+	printInstalled(infos)
 }
 
 func (that *FlutterVersion) removeTarFile(version string) {
@@ -350,14 +394,13 @@ func (that *FlutterVersion) GetAndroidSDKInfo() (androidSDKs map[string]string)
 	itemList := selector.NewItemList()
 	itemList.Add("from developer.android.google.cn", that.Conf.Flutter.AndroidCN)
 	itemList.Add("from developer.android.com", that.Conf.Flutter.Android)
-	sel := selector.NewSelector(
+	val := selectOne(
 		itemList,
-		selector.WidthEnableMulti(false),
+		"",
+		"",
 		selector.WithEnbleInfinite(true),
 		selector.WithTitle("Choose a download resource:"),
 	)
-	sel.Run()
-	val := sel.Value()[0]
 	if aUrl := val.(string); aUrl != "" {
 		dUrl := that.Conf.Flutter.AndroidCMDTooolsUrl
 		if strings.Contains(aUrl, ".cn") {
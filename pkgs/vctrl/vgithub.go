@@ -131,7 +131,11 @@ func (that *GhDownloader) downloadBinary(githubProjectUrl string) {
 				that.fetcher.Timeout = 30 * time.Minute
 				fPath := filepath.Join(that.path, selected)
 				if size := that.fetcher.GetAndSaveFile(fPath, true); size > 0 {
-					gprint.PrintSuccess(fPath)
+					if verifyGithubAsset(fPath, dUrl) {
+						gprint.PrintSuccess(fPath)
+					} else {
+						os.RemoveAll(fPath)
+					}
 				}
 			}
 		}
@@ -261,11 +265,10 @@ func (that *GhDownloader) downloadGitForWindows() {
 		os.MkdirAll(config.GitWindowsInstallationDir, os.ModePerm)
 	}
 	fPath := filepath.Join(config.GitFileDir, "git.7z")
-	gUrl = that.Conf.GVCProxy.WrapUrl(gUrl)
-	that.fetcher.SetUrl(gUrl)
+	that.fetcher.SetUrl(that.Conf.GVCProxy.WrapUrl(gUrl))
 	that.fetcher.SetThreadNum(2)
 	that.fetcher.Timeout = 10 * time.Minute
-	if err := that.fetcher.DownloadAndDecompress(fPath, config.GitWindowsInstallationDir, true); err != nil {
+	if err := downloadGithubAsset(that.fetcher, gUrl, fPath, config.GitWindowsInstallationDir); err != nil {
 		gprint.PrintError("%+v", err)
 	}
 }
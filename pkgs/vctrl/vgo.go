@@ -1,23 +1,22 @@
 package vctrl
 
 import (
-	"archive/zip"
-	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
 	"os"
-	"path"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/mholt/archiver/v3"
-	"github.com/moqsien/goutils/pkgs/gtea/confirm"
 	"github.com/moqsien/goutils/pkgs/gtea/gprint"
 	"github.com/moqsien/goutils/pkgs/gtea/gtable"
 	"github.com/moqsien/goutils/pkgs/gtea/selector"
@@ -40,9 +39,29 @@ type GoPackage struct {
 	CheckType string
 }
 
+/*
+Go download json api, like https://go.dev/dl/?mode=json&include=all
+*/
+type goJsonFile struct {
+	FileName string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Version  string `json:"version"`
+	Sha256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+	Kind     string `json:"kind"`
+}
+
+type goJsonVersion struct {
+	Version string        `json:"version"`
+	Stable  bool          `json:"stable"`
+	Files   []*goJsonFile `json:"files"`
+}
+
 type GoVersion struct {
 	Versions  map[string][]*GoPackage
 	Doc       *goquery.Document
+	jsonList  []*goJsonVersion
 	Conf      *config.GVConfig
 	ParsedUrl *url.URL
 	env       *utils.EnvsHandler
@@ -70,16 +89,16 @@ func (that *GoVersion) getDoc() {
 		itemList := selector.NewItemList()
 		itemList.Add("from go.dev", that.Conf.Go.CompilerUrls[1])
 		itemList.Add("from golang.google.cn", that.Conf.Go.CompilerUrls[0])
-		sel := selector.NewSelector(
+		source := rememberAnswer(that.Conf, &that.Conf.Go.DownloadSource, utils.Answers.Source)
+		val := selectOne(
 			itemList,
+			source,
+			"source",
 			selector.WithTitle("Choose a resource to download:"),
 			selector.WithEnbleInfinite(true),
-			selector.WidthEnableMulti(false),
 			selector.WithWidth(20),
 			selector.WithHeight(10),
 		)
-		sel.Run()
-		val := sel.Value()[0]
 		that.fetcher.Url = val.(string)
 
 		var err error
@@ -88,6 +107,9 @@ func (that *GoVersion) getDoc() {
 			os.Exit(1)
 		}
 		that.fetcher.Timeout = 30 * time.Second
+		if that.getJson() {
+			return
+		}
 		if resp := that.fetcher.Get(); resp != nil {
 			var err error
 			that.Doc, err = goquery.NewDocumentFromReader(resp.RawBody())
@@ -102,6 +124,88 @@ func (that *GoVersion) getDoc() {
 	}
 }
 
+// getJson gets versions from the json api.
+// Mirrors without the json api fall back to html pages.
+func (that *GoVersion) getJson() bool {
+	dUrl := that.fetcher.Url
+	defer func() {
+		that.fetcher.Url = dUrl
+	}()
+	q := that.ParsedUrl.Query()
+	q.Set("mode", "json")
+	q.Set("include", "all")
+	jUrl := *that.ParsedUrl
+	jUrl.RawQuery = q.Encode()
+	that.fetcher.Url = jUrl.String()
+	resp := that.fetcher.Get()
+	if resp == nil {
+		return false
+	}
+	content, _ := io.ReadAll(resp.RawBody())
+	jsonList := []*goJsonVersion{}
+	if err := json.Unmarshal(content, &jsonList); err != nil || len(jsonList) == 0 {
+		return false
+	}
+	that.jsonList = jsonList
+	return true
+}
+
+func (that *GoVersion) jsonPackages(v *goJsonVersion) (pkgs []*GoPackage) {
+	for _, f := range v.Files {
+		arch := f.Arch
+		if arch == "armv6l" {
+			arch = "arm"
+		}
+		pkgs = append(pkgs, &GoPackage{
+			FileName:  f.FileName,
+			Url:       that.ParsedUrl.JoinPath(f.FileName).String(),
+			AliUrl:    fmt.Sprintf("%s%s", that.Conf.Go.AliRepoUrl, f.FileName),
+			Kind:      f.Kind,
+			OS:        f.OS,
+			Arch:      arch,
+			Size:      fmt.Sprintf("%dMB", f.Size>>20),
+			Checksum:  f.Sha256,
+			CheckType: "SHA256",
+		})
+	}
+	return
+}
+
+// latestJsonVersions returns the latest releases of the two newest stable minor versions,
+// same as the "stable" part of go.dev/dl.
+func (that *GoVersion) latestJsonVersions() map[string]bool {
+	vList := []string{}
+	for _, v := range that.jsonList {
+		if v.Stable {
+			vList = append(vList, strings.TrimPrefix(v.Version, "go"))
+		}
+	}
+	vList = sorts.SortGoVersion(vList)
+	r := map[string]bool{}
+	minors := map[string]struct{}{}
+	for i := len(vList) - 1; i >= 0 && len(minors) < 2; i-- {
+		sList := strings.Split(vList[i], ".")
+		if len(sList) < 2 {
+			continue
+		}
+		minor := strings.Join(sList[:2], ".")
+		if _, ok := minors[minor]; !ok {
+			minors[minor] = struct{}{}
+			r[vList[i]] = true
+		}
+	}
+	return r
+}
+
+func (that *GoVersion) fillJsonVersions(filter func(vname string, v *goJsonVersion) bool) {
+	for _, v := range that.jsonList {
+		vname := strings.TrimPrefix(v.Version, "go")
+		if filter(vname, v) {
+			that.Versions[vname] = that.jsonPackages(v)
+		}
+	}
+}
+
 func (that *GoVersion) findPackages(table *goquery.Selection) (pkgs []*GoPackage) {
 	alg := strings.TrimSuffix(table.Find("thead").Find("th").Last().Text(), " Checksum")
 
@@ -148,6 +252,13 @@ func (that *GoVersion) hasUnstableVersions() bool {
 }
 
 func (that *GoVersion) StableVersions() (err error) {
+	if that.jsonList != nil {
+		latest := that.latestJsonVersions()
+		that.fillJsonVersions(func(vname string, v *goJsonVersion) bool {
+			return latest[vname]
+		})
+		return nil
+	}
 	var divs *goquery.Selection
 	if that.hasUnstableVersions() {
 		divs = that.Doc.Find("#stable").NextUntil("#unstable")
@@ -166,6 +277,12 @@ func (that *GoVersion) StableVersions() (err error) {
 }
 
 func (that *GoVersion) UnstableVersions() (err error) {
+	if that.jsonList != nil {
+		that.fillJsonVersions(func(vname string, v *goJsonVersion) bool {
+			return !v.Stable
+		})
+		return nil
+	}
 	that.Doc.Find("#unstable").NextUntil("#archive").Each(func(i int, div *goquery.Selection) {
 		vname, ok := div.Attr("id")
 		if !ok {
@@ -178,6 +295,13 @@ func (that *GoVersion) UnstableVersions() (err error) {
 }
 
 func (that *GoVersion) ArchivedVersions() (err error) {
+	if that.jsonList != nil {
+		latest := that.latestJsonVersions()
+		that.fillJsonVersions(func(vname string, v *goJsonVersion) bool {
+			return v.Stable && !latest[vname]
+		})
+		return nil
+	}
 	that.Doc.Find("#archive").Find("div.toggle").Each(func(i int, div *goquery.Selection) {
 		vname, ok := div.Attr("id")
 		if !ok {
@@ -190,7 +314,7 @@ func (that *GoVersion) ArchivedVersions() (err error) {
 }
 
 func (that *GoVersion) AllVersions() (err error) {
-	if that.Doc == nil {
+	if that.Doc == nil && that.jsonList == nil {
 		that.getDoc()
 	}
 	err = that.StableVersions()
@@ -234,31 +358,55 @@ func (that *GoVersion) filterVersionsForCurrentPlatform() (vList []string) {
 	return
 }
 
+func (that *GoVersion) printRemoteVersions() {
+	vList := sorts.SortGoVersion(that.filterVersionsForCurrentPlatform())
+	if !IsMachineOutput() {
+		fc := gprint.NewFadeColors(vList)
+		fc.Println()
+		return
+	}
+	current := strings.TrimSpace(strings.Split(that.getCurrent(), "\n")[0])
+	infos := []*VersionInfo{}
+	for _, v := range vList {
+		info := &VersionInfo{Version: v, OS: runtime.GOOS, Arch: runtime.GOARCH, Current: v == current}
+		for _, p := range that.Versions[v] {
+			if p.OS == runtime.GOOS && p.Arch == runtime.GOARCH && p.Kind == "archive" {
+				info.Url = p.Url
+				info.Checksum = p.Checksum
+			}
+		}
+		info.Installed, _ = utils.PathIsExist(filepath.Join(config.GoUnTarFilesPath, v))
+		infos = append(infos, info)
+	}
+	PrintVersionInfos(infos)
+}
+
 func (that *GoVersion) ShowRemoteVersions(arg string) {
-	if that.Doc == nil {
+	if that.Doc == nil && that.jsonList == nil {
 		that.getDoc()
 	}
 	switch arg {
 	case ShowAll:
 		if err := that.AllVersions(); err == nil {
-			fc := gprint.NewFadeColors(sorts.SortGoVersion(that.filterVersionsForCurrentPlatform()))
-			fc.Println()
+			that.printRemoteVersions()
 		}
 	case ShowStable:
 		if err := that.StableVersions(); err == nil {
-			fc := gprint.NewFadeColors(sorts.SortGoVersion(that.filterVersionsForCurrentPlatform()))
-			fc.Println()
+			that.printRemoteVersions()
 		}
 	case ShowUnstable:
 		if err := that.UnstableVersions(); err == nil {
-			fc := gprint.NewFadeColors(sorts.SortGoVersion(that.filterVersionsForCurrentPlatform()))
-			fc.Println()
+			that.printRemoteVersions()
 		}
 	default:
 		gprint.PrintWarning(fmt.Sprintf("Unknown show type: %s", arg))
 	}
 }
 
+func (that *GoVersion) ShowVersions() {
+	that.ShowRemoteVersions(ShowStable)
+}
+
 func (that *GoVersion) findPackage(version string, kind ...string) (p *GoPackage) {
 	k := "archive"
 	if len(kind) > 0 {
@@ -278,20 +426,21 @@ func (that *GoVersion) findPackage(version string, kind ...string) (p *GoPackage
 func (that *GoVersion) download(version string) (r string) {
 	p := that.findPackage(version)
 	if p != nil {
-		cfm := confirm.NewConfirm(confirm.WithTitle("Use mirrors.aliyun.com/golang for download acceleration?"))
-		cfm.Run()
-		if cfm.Result() {
+		mirror := rememberAnswer(that.Conf, &that.Conf.Go.UseMirror, utils.Answers.Mirror)
+		if confirmOrNot("Use mirrors.aliyun.com/golang for download acceleration?", mirror, "mirror") {
 			that.fetcher.Url = p.AliUrl
 			if that.fetcher.Url == "" {
 				that.fetcher.Url = p.Url
 			}
+		} else {
+			that.fetcher.Url = p.Url
 		}
 		that.fetcher.Timeout = 900 * time.Second
 		that.fetcher.SetThreadNum(4)
 
 		fName := fmt.Sprintf("go-%s-%s.%s%s", version, p.OS, p.Arch, utils.GetExt(p.FileName))
 		fpath := filepath.Join(config.GoTarFilesPath, fName)
-		if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
+		if size := cachedDownload(that.fetcher, fpath, p.CheckType, p.Checksum); size > 0 {
 			if ok := that.checkFile(p, fpath); ok {
 				return fpath
 			} else {
@@ -305,7 +454,19 @@ func (that *GoVersion) download(version string) (r string) {
 }
 
 func (that *GoVersion) checkFile(p *GoPackage, fpath string) (r bool) {
-	return utils.CheckFile(fpath, p.CheckType, p.Checksum)
+	if !utils.VerifyFile(fpath, p.CheckType, p.Checksum) {
+		return false
+	}
+	// mirrors do not provide signatures, they are downloaded from the official site.
+	sigBase := that.Conf.Go.SignatureUrl
+	if sigBase == "" {
+		// configs of older versions have no signature url.
+		cnf := config.NewGoConf()
+		cnf.Reset()
+		sigBase = cnf.SignatureUrl
+	}
+	sigUrl, _ := url.JoinPath(sigBase, p.FileName+".asc")
+	return verifyDetachedSignature(fpath, sigUrl)
 }
 
 func (that *GoVersion) CheckAndInitEnv() {
@@ -342,10 +503,22 @@ func (that *GoVersion) CheckAndInitEnv() {
 	}
 }
 
-func (that *GoVersion) UseVersion(version string) {
+// InstallVersion downloads and unarchives a version without switching to it.
+// Returns the GOROOT of the installed version.
+func (that *GoVersion) InstallVersion(version string) (goRoot string) {
+	version = resolveOrInstalled(that, "go", version, config.GoUnTarFilesPath)
+	return that.install(version, that.download)
+}
+
+// InstallArchive unarchives a local archive file as InstallVersion does after download.
+func (that *GoVersion) InstallArchive(version, archive string) (goRoot string) {
+	return that.install(version, func(string) string { return archive })
+}
+
+func (that *GoVersion) install(version string, getArchive func(version string) string) (goRoot string) {
 	untarfile := filepath.Join(config.GoUnTarFilesPath, version)
 	if ok, _ := utils.PathIsExist(untarfile); !ok {
-		if tarfile := that.download(version); tarfile != "" {
+		if tarfile := getArchive(version); tarfile != "" {
 			if err := archiver.Unarchive(tarfile, untarfile); err != nil {
 				os.RemoveAll(untarfile)
 				gprint.PrintError(fmt.Sprintf("Unarchive failed: %+v.", err))
@@ -357,17 +530,29 @@ func (that *GoVersion) UseVersion(version string) {
 			return
 		}
 	}
+	return filepath.Join(untarfile, "go")
+}
+
+func (that *GoVersion) UseVersion(version string) bool {
+	// constraints are resolved by InstallVersion, the version dir is the resolved version.
+	goRoot := that.InstallVersion(version)
+	if goRoot == "" {
+		return false
+	}
+	version = filepath.Base(filepath.Dir(goRoot))
 	if ok, _ := utils.PathIsExist(config.DefaultGoRoot); ok {
 		os.RemoveAll(config.DefaultGoRoot)
 	}
-	if err := utils.MkSymLink(filepath.Join(untarfile, "go"), config.DefaultGoRoot); err != nil {
+	if err := utils.MkSymLink(goRoot, config.DefaultGoRoot); err != nil {
 		gprint.PrintError(fmt.Sprintf("Create link failed: %+v.", err))
-		return
+		return false
 	}
 	if !that.env.DoesEnvExist(utils.SUB_GO) {
 		that.CheckAndInitEnv()
 	}
 	gprint.PrintSuccess(fmt.Sprintf("Use %s succeeded!", version))
+	syncToolsAfterSwitch(that.Conf, ToolsGo)
+	return true
 }
 
 func (that *GoVersion) getCurrent() (current string) {
@@ -387,15 +572,19 @@ func (that *GoVersion) ShowInstalled() {
 		gprint.PrintError(fmt.Sprintf("Read dir failed: %+v", err))
 		return
 	}
+	infos := []*VersionInfo{}
 	for _, v := range installedList {
 		if v.IsDir() {
-			if strings.Contains(current, v.Name()) {
-				gprint.Yellow("%s <Current>", v.Name())
-				continue
-			}
-			gprint.Cyan(v.Name())
+			infos = append(infos, &VersionInfo{
+				Version:   v.Name(),
+				OS:        runtime.GOOS,
+				Arch:      runtime.GOARCH,
+				Installed: true,
+				Current:   current != "" && strings.Contains(current, v.Name()),
+			})
 		}
 	}
+	printInstalled(infos)
 }
 
 func (that *GoVersion) parseTarFileName(name string) (v string) {
@@ -505,6 +694,23 @@ type GoBuildArchOS struct {
 	ArchOSList []string `koanf:"arch_os_list"`
 	Compress   bool     `koanf:"compress"`
 	BuildArgs  []string `koanf:"build_args"`
+	// number of targets built at the same time, defaults to the number of cpus.
+	Parallel int `koanf:"parallel"`
+	// archive format for compressed binaries, zip or tar.gz, "zip" by default.
+	ArchiveFormat string `koanf:"archive_format"`
+	// archive formats by GOOS, like {"linux": "tar.gz"}, override ArchiveFormat.
+	ArchiveFormats map[string]string `koanf:"archive_formats"`
+	// files or dirs bundled into every archive, like README.md, LICENSE, configs.
+	ExtraFiles []string `koanf:"extra_files"`
+	// write SHA256SUMS and manifest.json for artifacts in the build dir.
+	Checksums bool `koanf:"checksums"`
+	Manifest  bool `koanf:"manifest"`
+	// variables stamped with git tag, commit, dirty state and build time via -ldflags -X.
+	VersionVars *GoBuildVersionVars `koanf:"version_vars"`
+	// build with CGO_ENABLED=1, CC and CXX are set to zig for each target.
+	Cgo bool `koanf:"cgo"`
+	// zig target triples by os/arch, like {"linux/amd64": "x86_64-linux-musl"}.
+	CgoTargets map[string]string `koanf:"cgo_targets"`
 }
 
 func (that *GoVersion) getGoDistlist() []string {
@@ -537,42 +743,6 @@ func (that *GoVersion) ShowGoDistlist() {
 	fc.Println()
 }
 
-func (that *GoVersion) zip(src, dst, binName string) (err error) {
-	fr, err := os.Open(src)
-	if err != nil {
-		return
-	}
-	defer fr.Close()
-
-	info, err := fr.Stat()
-	if err != nil || info.IsDir() {
-		return err
-	}
-	header, err := zip.FileInfoHeader(info)
-	if err != nil {
-		return err
-	}
-
-	fw, err := os.Create(dst)
-	if err != nil {
-		return
-	}
-	defer fw.Close()
-	header.Name = binName
-	header.Method = zip.Deflate
-	zw := zip.NewWriter(fw)
-	writer, err := zw.CreateHeader(header)
-	if err != nil {
-		return
-	}
-	defer zw.Close()
-
-	if _, err = io.Copy(writer, fr); err != nil {
-		return
-	}
-	return nil
-}
-
 func (that *GoVersion) findCompiledBinary(binaryStoreDir string) (bPath string) {
 	if fList, err := os.ReadDir(binaryStoreDir); err == nil {
 		for _, f := range fList {
@@ -584,82 +754,168 @@ func (that *GoVersion) findCompiledBinary(binaryStoreDir string) (bPath string)
 	return
 }
 
+// hasOutputFlag reports whether -o is specified in build args, like -o bin/app or -o=bin/app.
+func hasOutputFlag(buildArgs []string) bool {
+	for _, v := range buildArgs {
+		if v == "-o" || strings.HasPrefix(v, "-o=") {
+			return true
+		}
+	}
+	return false
+}
+
 func (that *GoVersion) getBuildArgs(buildArgs []string, binaryStoreDir string) (r []string) {
-	hasOuput := false
 	for _, v := range buildArgs {
-		if v == "-o" {
-			hasOuput = true
-		} else if v == "." {
+		if v == "." {
 			continue
 		}
 		r = append(r, v)
 	}
-	if !hasOuput {
+	if !hasOutputFlag(buildArgs) {
 		r = append(r, "-o", binaryStoreDir)
 	}
 	return
 }
 
-func (that *GoVersion) build(buildArgs []string, buildBaseDir, archOS string, toGzip bool) {
+type goBuildResult struct {
+	ArchOS   string
+	Ok       bool
+	Duration time.Duration
+	LogPath  string
+	Artifact string
+	Err      error
+}
+
+// build compiles for one target, GOOS and GOARCH are only set in the env of the go command,
+// so targets can be built concurrently. Output is captured in build/logs/<os>-<arch>.log.
+func (that *GoVersion) build(buildArgs []string, buildBaseDir, archOS string, bConf *GoBuildArchOS) (r *goBuildResult) {
+	r = &goBuildResult{ArchOS: archOS}
+	start := time.Now()
+	defer func() {
+		r.Duration = time.Since(start)
+		r.Ok = r.Err == nil
+	}()
+
 	gprint.PrintInfo(fmt.Sprintf("Compiling for %s...", archOS))
 	dirName := strings.ReplaceAll(archOS, "/", "-")
 	infoList := strings.Split(archOS, "/")
-	if len(infoList) == 2 {
-		pOs, pArch := infoList[0], infoList[1]
-		binaryStoreDir := filepath.Join(buildBaseDir, dirName)
-		if ok, _ := utils.PathIsExist(binaryStoreDir); !ok {
-			if err := os.MkdirAll(binaryStoreDir, os.ModePerm); err != nil {
-				gprint.PrintError("%+v", err)
-				return
-			}
+	if len(infoList) != 2 {
+		r.Err = fmt.Errorf("invalid target: %s", archOS)
+		return
+	}
+	pOs, pArch := infoList[0], infoList[1]
+	binaryStoreDir := filepath.Join(buildBaseDir, dirName)
+	if ok, _ := utils.PathIsExist(binaryStoreDir); !ok {
+		if r.Err = os.MkdirAll(binaryStoreDir, os.ModePerm); r.Err != nil {
+			return
 		}
-		os.Setenv("GOOS", pOs)
-		os.Setenv("GOARCH", pArch)
-		cmdArgs := []string{"go", "build"}
+	}
+	logDir := filepath.Join(buildBaseDir, "logs")
+	utils.MakeDirs(logDir)
+	r.LogPath = filepath.Join(logDir, dirName+".log")
+	logFile, err := os.Create(r.LogPath)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	defer logFile.Close()
 
-		var targetDir string
-		if len(buildArgs) > 0 {
-			lastArg := buildArgs[len(buildArgs)-1]
-			if ok, _ := utils.PathIsExist(lastArg); ok {
-				targetDir = lastArg
-				buildArgs = buildArgs[:len(buildArgs)-1]
-			}
+	cmdArgs := []string{"build"}
+	var targetDir string
+	if len(buildArgs) > 0 {
+		lastArg := buildArgs[len(buildArgs)-1]
+		if ok, _ := utils.PathIsExist(lastArg); ok {
+			targetDir = lastArg
+			buildArgs = buildArgs[:len(buildArgs)-1]
 		}
+	}
 
-		if !strings.Contains(strings.Join(buildArgs, " "), "-ldflags") {
-			cmdArgs = append(cmdArgs, "-ldflags", `-s -w`)
-		}
+	if !strings.Contains(strings.Join(buildArgs, " "), "-ldflags") {
+		cmdArgs = append(cmdArgs, "-ldflags", `-s -w`)
+	}
 
-		bArgs := that.getBuildArgs(buildArgs, binaryStoreDir)
-		cmdArgs = append(cmdArgs, bArgs...)
+	bArgs := that.getBuildArgs(buildArgs, binaryStoreDir)
+	cmdArgs = append(cmdArgs, bArgs...)
 
-		if targetDir != "" {
-			cmdArgs = append(cmdArgs, targetDir)
-		}
+	if targetDir != "" {
+		cmdArgs = append(cmdArgs, targetDir)
+	}
 
-		if _, err := utils.ExecuteSysCommand(false, cmdArgs...); err != nil {
-			gprint.PrintError("%+v", err)
-		} else if toGzip {
-			gprint.PrintSuccess(fmt.Sprintf("Compilation for %s succeeded.", archOS))
-			binPath := that.findCompiledBinary(binaryStoreDir)
-			nList := strings.Split(binPath, string(filepath.Separator))
-			binName := nList[len(nList)-1]
-			binSuffix := path.Ext(binPath)
-			name := strings.TrimSuffix(binName, binSuffix)
-			tarFilePath := strings.Join([]string{buildBaseDir, fmt.Sprintf(`%s_%s.zip`, name, dirName)}, string(filepath.Separator))
-			if ok, _ := utils.PathIsExist(tarFilePath); ok {
-				os.RemoveAll(tarFilePath)
-			}
+	cgoEnv, err := bConf.cgoEnv(pOs, pArch)
+	if err != nil {
+		r.Err = err
+		fmt.Fprintln(logFile, err)
+		gprint.PrintError(fmt.Sprintf("Compilation for %s failed: %+v", archOS, err))
+		return
+	}
+	envs := append([]string{"GOOS=" + pOs, "GOARCH=" + pArch}, cgoEnv...)
+	cmd := exec.Command("go", cmdArgs...)
+	cmd.Env = append(os.Environ(), envs...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	fmt.Fprintf(logFile, "%s go %s\n", strings.Join(envs, " "), strings.Join(cmdArgs, " "))
+	if r.Err = cmd.Run(); r.Err != nil {
+		gprint.PrintError(fmt.Sprintf("Compilation for %s failed, see %s.", archOS, r.LogPath))
+		return
+	}
+	gprint.PrintSuccess(fmt.Sprintf("Compilation for %s succeeded.", archOS))
+	r.Artifact = that.findCompiledBinary(binaryStoreDir)
+	if !bConf.Compress {
+		return
+	}
 
-			if err := that.zip(binPath, tarFilePath, binName); err != nil {
-				gprint.PrintError("%+v", err)
-			} else {
-				gprint.PrintSuccess(fmt.Sprintf("Compression for %s succeeded.", archOS))
+	if r.Artifact, r.Err = that.archive(r.Artifact, buildBaseDir, pOs, dirName, bConf); r.Err != nil {
+		gprint.PrintError(fmt.Sprintf("Compression for %s failed: %+v", archOS, r.Err))
+	} else {
+		gprint.PrintSuccess(fmt.Sprintf("Compression for %s succeeded.", archOS))
+	}
+	return
+}
+
+// buildAll builds targets with a pool of parallel workers, results are in the order of targets.
+func (that *GoVersion) buildAll(buildArgs []string, buildBaseDir string, archOSList []string, bConf *GoBuildArchOS) (results []*goBuildResult) {
+	parallel := bConf.Parallel
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+	results = make([]*goBuildResult, len(archOSList))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallel && i < len(archOSList); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				// build modifies the slice of args.
+				args := append([]string{}, buildArgs...)
+				results[idx] = that.build(args, buildBaseDir, archOSList[idx], bConf)
 			}
+		}()
+	}
+	for idx := range archOSList {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	return
+}
+
+func printBuildResults(results []*goBuildResult) (failed int) {
+	fmt.Println()
+	gprint.Yellow("%-24s%-10s%-12s%s", "Target", "Status", "Duration", "Log")
+	for _, r := range results {
+		d := r.Duration.Round(10 * time.Millisecond).String()
+		if r.Ok {
+			gprint.Green("%-24s%-10s%-12s%s", r.ArchOS, "ok", d, r.LogPath)
+			continue
 		}
-	} else {
-		gprint.PrintError(archOS)
+		failed++
+		gprint.Red("%-24s%-10s%-12s%s", r.ArchOS, "failed", d, r.LogPath)
 	}
+	if failed > 0 {
+		gprint.PrintError(fmt.Sprintf("%d of %d targets failed.", failed, len(results)))
+	}
+	return
 }
 
 // parse args by executing shell commands
@@ -687,24 +943,25 @@ func (that *GoVersion) handleBuildArgs(buildArgs ...string) (args []string) {
 	return
 }
 
-func (that *GoVersion) Build(args ...string) {
+// Build compiles for all targets, returns false if any target fails.
+func (that *GoVersion) Build(args ...string) bool {
 	goRoot := os.Getenv("GOROOT")
 	if ok, _ := utils.PathIsExist(goRoot); !ok {
 		gprint.PrintError("Cannot find a go compiler.")
 		gprint.PrintInfo(`You can install a go compiler using gvc. See help info by "gvc go help".`)
-		return
+		return false
 	}
 
 	if ok, _ := utils.PathIsExist("go.mod"); !ok {
 		gprint.PrintError("Cannot find go.mod file. Please check your present working directory.")
-		return
+		return false
 	}
 
 	buildDir := "build"
 	if ok, _ := utils.PathIsExist(buildDir); !ok {
 		if err := os.MkdirAll(buildDir, os.ModePerm); err != nil {
 			gprint.PrintError("%+v", err)
-			return
+			return false
 		}
 	}
 
@@ -725,6 +982,24 @@ func (that *GoVersion) Build(args ...string) {
 	}
 	if ok, _ := utils.PathIsExist(buildConfig); ok {
 		kfer.Load(bConf)
+		if len(utils.Answers.Targets) > 0 {
+			bConf.ArchOSList = utils.Answers.Targets
+		}
+		if utils.Answers.Compress != "" {
+			bConf.Compress = confirmOrNot("", utils.Answers.Compress, "compress")
+		}
+		kfer.Save(bConf)
+	} else if len(utils.Answers.Targets) > 0 || !utils.CanPrompt() {
+		bConf.ArchOSList = utils.Answers.Targets
+		if len(bConf.ArchOSList) == 0 {
+			if !utils.Answers.AssumeYes {
+				exitForPrompt("targets")
+			}
+			bConf.ArchOSList = []string{fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)}
+		}
+		if utils.Answers.Compress != "" {
+			bConf.Compress = confirmOrNot("", utils.Answers.Compress, "compress")
+		}
 		kfer.Save(bConf)
 	} else {
 		itemList := selector.NewItemList()
@@ -754,78 +1029,34 @@ func (that *GoVersion) Build(args ...string) {
 			bConf.ArchOSList = append(bConf.ArchOSList, val.([]string)...)
 		}
 
-		cfm := confirm.NewConfirm(confirm.WithTitle("To compress binaries or not?"))
-		cfm.Run()
-		bConf.Compress = cfm.Result()
+		bConf.Compress = confirmOrNot("To compress binaries or not?", utils.Answers.Compress, "compress")
 		kfer.Save(bConf)
 	}
 
+	if utils.Answers.Parallel > 0 {
+		bConf.Parallel = utils.Answers.Parallel
+	}
+
 	alreadyBuilt := map[string]struct{}{}
+	archOSList := []string{}
 	for _, archOS := range bConf.ArchOSList {
 		if _, ok := alreadyBuilt[archOS]; ok {
 			continue
 		}
-		buildArgs := that.handleBuildArgs(bConf.BuildArgs...)
-		that.build(buildArgs, buildDir, archOS, bConf.Compress)
+		archOSList = append(archOSList, archOS)
 		alreadyBuilt[archOS] = struct{}{}
 	}
-}
-
-// Rename local go module
-func (that *GoVersion) getOldModuleName(moduleDir string) string {
-	var (
-		modFileName = "go.mod"
-		keyword     = "module"
-	)
-	if eList, err := os.ReadDir(moduleDir); err == nil {
-		for _, entry := range eList {
-			if entry.Name() == modFileName {
-				// open the file
-				file, err := os.Open(filepath.Join(moduleDir, entry.Name()))
-				if err != nil {
-					gprint.PrintError("%+v", err)
-					return ""
-				}
-				defer file.Close()
-				fileScanner := bufio.NewScanner(file)
-				for fileScanner.Scan() {
-					t := fileScanner.Text()
-					if strings.Contains(t, keyword) {
-						sList := strings.Split(t, keyword)
-						return strings.TrimSpace(sList[1])
-					}
-				}
-				if err := fileScanner.Err(); err != nil {
-					gprint.PrintError("%+v", err)
-				}
-			}
-		}
-	}
-	gprint.PrintError(fmt.Sprintf("Can not find module name in [%s].", filepath.Join(moduleDir, modFileName)))
-	return ""
-}
-
-func (that *GoVersion) renameModule(pathStr, oldName, newName string, isDir bool) {
-	if isDir {
-		if eList, err := os.ReadDir(pathStr); err == nil {
-			for _, entry := range eList {
-				that.renameModule(filepath.Join(pathStr, entry.Name()), oldName, newName, entry.IsDir())
-			}
-		}
-	} else {
-		if strings.HasSuffix(pathStr, "go.mod") || strings.HasSuffix(pathStr, ".go") {
-			if content, err := os.ReadFile(pathStr); err == nil {
-				newStr := strings.ReplaceAll(string(content), oldName, newName)
-				os.WriteFile(pathStr, []byte(newStr), os.ModePerm)
-			}
-		}
+	buildArgs := injectVersionVars(that.handleBuildArgs(bConf.BuildArgs...), bConf.VersionVars)
+	if hasOutputFlag(buildArgs) && len(archOSList) > 1 {
+		// parallel targets would overwrite the same output file.
+		gprint.PrintError("-o cannot be used with multiple targets, binaries are written to build/<os>-<arch>.")
+		return false
 	}
-}
-
-func (that *GoVersion) RenameLocalModule(moduleDir, newName string) {
-	oldName := that.getOldModuleName(moduleDir)
-	if oldName == "" {
-		return
+	results := that.buildAll(buildArgs, buildDir, archOSList, bConf)
+	failed := printBuildResults(results)
+	if err := that.writeReleaseFiles(buildDir, results, bConf); err != nil {
+		gprint.PrintError("%+v", err)
+		return false
 	}
-	that.renameModule(moduleDir, oldName, newName, true)
+	return failed == 0
 }
@@ -35,6 +35,11 @@ func (that *VGSudo) Install(force bool) {
 	}
 	that.fetcher.Url = that.Conf.GVCProxy.WrapUrl(that.Conf.GSudo.Url)
 	if that.fetcher.Url != "" {
+		cType, cSum, ok := requireGithubDigest(that.Conf.GSudo.Url)
+		if !ok {
+			return
+		}
+		that.fetcher.SetCheckSum(cSum, cType)
 		that.fetcher.Timeout = 20 * time.Minute
 		that.fetcher.SetThreadNum(2)
 		fPath := filepath.Join(config.GsudoFilePath, "gsudo.zip")
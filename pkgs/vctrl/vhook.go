@@ -0,0 +1,209 @@
+package vctrl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+	"github.com/moqsien/gvc/pkgs/utils/sorts"
+)
+
+/*
+Shell hooks that switch versions on cd, for the present shell session only.
+*/
+const (
+	HookPathsEnvName  = "GVC_HOOK_PATHS"
+	HookOriginEnvName = "GVC_HOOK_ORIG_%s"
+)
+
+var hookScripts = map[string]string{
+	utils.Bash: `_gvc_hook() {
+  local previous_exit_status=$?
+  if [[ "${_GVC_LAST_PWD:-}" != "$PWD" ]]; then
+    _GVC_LAST_PWD="$PWD"
+    eval "$(GVC_NON_INTERACTIVE=1 %[1]s hook-env bash)"
+  fi
+  return $previous_exit_status
+}
+if [[ ";${PROMPT_COMMAND[*]:-};" != *";_gvc_hook;"* ]]; then
+  PROMPT_COMMAND="_gvc_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+`,
+	utils.Zsh: `_gvc_hook() {
+  eval "$(GVC_NON_INTERACTIVE=1 %[1]s hook-env zsh)"
+}
+typeset -ag chpwd_functions
+if (( ! ${chpwd_functions[(I)_gvc_hook]} )); then
+  chpwd_functions=(_gvc_hook $chpwd_functions)
+fi
+_gvc_hook
+`,
+	utils.Fish: `function _gvc_hook --on-variable PWD
+    GVC_NON_INTERACTIVE=1 %[1]s hook-env fish | source
+end
+_gvc_hook
+`,
+}
+
+func checkHookShell(shell string) error {
+	if _, ok := hookScripts[shell]; !ok {
+		return fmt.Errorf("unsupported shell: %s, available: bash, zsh, fish", shell)
+	}
+	return nil
+}
+
+// HookScript returns the script to eval in rc files, like: eval "$(gvc hook bash)".
+func HookScript(shell string) (string, error) {
+	if err := checkHookShell(shell); err != nil {
+		return "", err
+	}
+	exePath, err := os.Executable()
+	if err != nil {
+		exePath = "gvc"
+	}
+	return fmt.Sprintf(hookScripts[shell], utils.QuoteForShell(shell, exePath)), nil
+}
+
+// HookEnv returns the commands that export envs for versions pinned in dir,
+// and restore envs set for the previous dir.
+func HookEnv(shell, dir string) (string, error) {
+	if err := checkHookShell(shell); err != nil {
+		return "", err
+	}
+	pinned, _ := GetPinnedVersions(dir)
+	homes := map[string]string{}
+	binDirs := []string{}
+	for _, p := range pinned {
+		s := findSDK(p.Name)
+		if s == nil || s.HomeEnv == "" || s.FindInstalled == nil {
+			continue
+		}
+		if home := s.FindInstalled(p.Version); home != "" {
+			homes[s.HomeEnv] = home
+			binDirs = append(binDirs, filepath.Join(home, "bin"))
+		}
+	}
+
+	lines := []string{}
+	for _, s := range sdkList {
+		if s.HomeEnv == "" {
+			continue
+		}
+		originName := fmt.Sprintf(HookOriginEnvName, s.HomeEnv)
+		origin, saved := os.LookupEnv(originName)
+		if home, ok := homes[s.HomeEnv]; ok {
+			if !saved {
+				lines = append(lines, utils.ExportLine(shell, originName, os.Getenv(s.HomeEnv)))
+			}
+			lines = append(lines, utils.ExportLine(shell, s.HomeEnv, home))
+		} else if saved {
+			if origin != "" {
+				lines = append(lines, utils.ExportLine(shell, s.HomeEnv, origin))
+			} else {
+				lines = append(lines, utils.UnsetLine(shell, s.HomeEnv))
+			}
+			lines = append(lines, utils.UnsetLine(shell, originName))
+		}
+	}
+
+	oldBinDirs := filepath.SplitList(os.Getenv(HookPathsEnvName))
+	if len(binDirs) > 0 || len(oldBinDirs) > 0 {
+		pathList := append([]string{}, binDirs...)
+		for _, p := range filepath.SplitList(os.Getenv("PATH")) {
+			if !containsString(oldBinDirs, p) && !containsString(binDirs, p) {
+				pathList = append(pathList, p)
+			}
+		}
+		lines = append(lines, utils.ExportLine(shell, "PATH", strings.Join(pathList, string(os.PathListSeparator))))
+		if len(binDirs) > 0 {
+			lines = append(lines, utils.ExportLine(shell, HookPathsEnvName, strings.Join(binDirs, string(os.PathListSeparator))))
+		} else {
+			lines = append(lines, utils.UnsetLine(shell, HookPathsEnvName))
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func containsString(sList []string, s string) bool {
+	for _, v := range sList {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// findVersionDir finds the dir for versions like "1.21.5", "1.21" or "~1.20" in baseDir, the newest wins.
+func findVersionDir(baseDir, version string) string {
+	if version == "" {
+		return ""
+	}
+	if ok, _ := utils.PathIsExist(filepath.Join(baseDir, version)); ok {
+		return filepath.Join(baseDir, version)
+	}
+	isConstraint := sorts.IsVersionConstraint(version)
+	names := map[string]string{}
+	vList := []string{}
+	if dList, err := os.ReadDir(baseDir); err == nil {
+		for _, d := range dList {
+			if ok, _ := isDir(filepath.Join(baseDir, d.Name())); ok && (isConstraint || strings.HasPrefix(d.Name(), version+".")) {
+				v := strings.TrimPrefix(d.Name(), "v")
+				names[v] = d.Name()
+				vList = append(vList, v)
+			}
+		}
+	}
+	if isConstraint {
+		if v, ok := sorts.ResolveConstraint(version, vList); ok {
+			return filepath.Join(baseDir, names[v])
+		}
+	}
+	if len(vList) == 0 || isConstraint {
+		return ""
+	}
+	vList = sorts.SortGoVersion(vList)
+	return filepath.Join(baseDir, names[vList[len(vList)-1]])
+}
+
+// findHomeWithBin finds the dir containing bin/<binary>.
+func findHomeWithBin(dir, binary string) string {
+	if dir == "" {
+		return ""
+	}
+	for _, name := range []string{binary, binary + ".exe"} {
+		if isFile(filepath.Join(dir, "bin", name)) {
+			return dir
+		}
+	}
+	if dList, err := os.ReadDir(dir); err == nil {
+		for _, d := range dList {
+			if d.IsDir() {
+				if home := findHomeWithBin(filepath.Join(dir, d.Name()), binary); home != "" {
+					return home
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func findInstalledGo(version string) string {
+	if d := findVersionDir(config.GoUnTarFilesPath, version); d != "" {
+		return findHomeWithBin(filepath.Join(d, "go"), "go")
+	}
+	return ""
+}
+
+func findInstalledJava(version string) string {
+	return findHomeWithBin(findVersionDir(config.JavaUnTarFilesPath, version), "java")
+}
+
+func findInstalledNode(version string) string {
+	if !sorts.IsVersionConstraint(version) {
+		version = "v" + strings.TrimPrefix(version, "v")
+	}
+	return findHomeWithBin(findVersionDir(config.NodejsUntarFiles, version), "node")
+}
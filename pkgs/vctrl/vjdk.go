@@ -26,12 +26,15 @@ var AllowedSuffixes = []string{
 }
 
 type JDKPackage struct {
-	Url      string
-	FileName string
-	OS       string
-	Arch     string
-	Size     string
-	Checksum string
+	Url       string
+	FileName  string
+	OS        string
+	Arch      string
+	Size      string
+	Checksum  string
+	CheckType string
+	Vendor    string
+	Version   string
 }
 
 type JDKVersion struct {
@@ -102,16 +105,16 @@ func (that *JDKVersion) GetVersions() {
 	itemList := selector.NewItemList()
 	itemList.Add("from injdk.cn", false)
 	itemList.Add("from oracle.com", true)
-	sel := selector.NewSelector(
+	source := rememberAnswer(that.Conf, &that.Conf.Java.DownloadSource, utils.Answers.Source)
+	val := selectOne(
 		itemList,
+		source,
+		"source",
 		selector.WithTitle("Choose resources to download:"),
-		selector.WidthEnableMulti(false),
 		selector.WithEnbleInfinite(true),
 		selector.WithWidth(30),
 		selector.WithHeight(10),
 	)
-	sel.Run()
-	val := sel.Value()[0]
 	isOfficial := val.(bool)
 
 	if that.Doc == nil {
@@ -225,8 +228,27 @@ func (that *JDKVersion) ShowVersions() {
 		vList = append(vList, k)
 	}
 	vList = sorts.SortJDKVersion(vList)
-	fc := gprint.NewFadeColors(vList)
-	fc.Println()
+	if !IsMachineOutput() {
+		fc := gprint.NewFadeColors(vList)
+		fc.Println()
+		return
+	}
+	// JAVA_VERSION is like 17.0.2 or 1.8.0_392.
+	current := normalizeVersion("java", that.getCurrent())
+	infos := []*VersionInfo{}
+	for _, v := range vList {
+		info := &VersionInfo{Version: v, OS: runtime.GOOS, Arch: runtime.GOARCH}
+		for _, p := range that.Versions[v] {
+			if p.OS == runtime.GOOS && p.Arch == runtime.GOARCH {
+				info.Url = p.Url
+				info.Checksum = p.Checksum
+			}
+		}
+		info.Installed, _ = utils.PathIsExist(filepath.Join(config.JavaUnTarFilesPath, v))
+		info.Current = current != "" && normalizeVersion("java", v) == current
+		infos = append(infos, info)
+	}
+	PrintVersionInfos(infos)
 }
 
 func (that *JDKVersion) findVersion(version string) (p *JDKPackage) {
@@ -254,23 +276,7 @@ func (that *JDKVersion) download(version string) (r string) {
 		if strings.Contains(p.Url, "oracle.com") {
 			p.Url = that.Conf.GVCProxy.WrapUrl(p.Url)
 		}
-		that.fetcher.Url = p.Url
-		that.fetcher.Timeout = 100 * time.Minute
-		that.fetcher.SetThreadNum(8)
-		fpath := filepath.Join(config.JavaTarFilesPath, p.FileName)
-		if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
-			if p.Checksum != "" {
-				if ok := utils.CheckFile(fpath, "sha256", p.Checksum); ok {
-					return fpath
-				} else {
-					os.RemoveAll(fpath)
-				}
-			} else {
-				return fpath
-			}
-		} else {
-			os.RemoveAll(fpath)
-		}
+		return that.downloadPackage(p)
 	} else {
 		gprint.PrintError(fmt.Sprintf("Invalid jdk version: %s", version))
 		gprint.PrintInfo("Versions available: ")
@@ -279,6 +285,27 @@ func (that *JDKVersion) download(version string) (r string) {
 	return
 }
 
+func (that *JDKVersion) downloadPackage(p *JDKPackage) (r string) {
+	if p == nil {
+		return
+	}
+	cType := p.CheckType
+	if cType == "" {
+		cType = "sha256"
+	}
+	that.fetcher.Url = p.Url
+	that.fetcher.Timeout = 100 * time.Minute
+	that.fetcher.SetThreadNum(8)
+	fpath := filepath.Join(config.JavaTarFilesPath, p.FileName)
+	if size := cachedDownload(that.fetcher, fpath, cType, p.Checksum); size > 0 {
+		if ok := utils.VerifyFile(fpath, cType, p.Checksum); ok {
+			return fpath
+		}
+	}
+	os.RemoveAll(fpath)
+	return
+}
+
 func (that *JDKVersion) CheckAndInitEnv() {
 	if runtime.GOOS != utils.Windows {
 		javaEnv := fmt.Sprintf(utils.JavaEnv, config.DefaultJavaRoot)
@@ -307,10 +334,46 @@ func (that *JDKVersion) findDir(untarfile string) {
 	}
 }
 
-func (that *JDKVersion) UseVersion(version string) {
+// InstallVersion downloads and unarchives a version without switching to it.
+// Returns the JAVA_HOME of the installed version.
+// Versions with a vendor, like temurin@21, are downloaded with the api of the vendor.
+func (that *JDKVersion) InstallVersion(version string) (javaHome string) {
+	if vendor, v, ok := parseJDKVendor(version); ok {
+		dirName, p := that.resolveVendor(vendor, v)
+		if dirName == "" {
+			return
+		}
+		if p != nil {
+			// archives are named like the versions dir, so that removeTarFile can find them.
+			p.FileName = fmt.Sprintf("%s-%s_%s%s", dirName, runtime.GOOS, runtime.GOARCH, that.GetFileSuffix(p.FileName))
+		}
+		return that.install(dirName, func(string) string { return that.downloadPackage(p) })
+	}
+	// versions like 11 or jdk11 use adopted system jdks before downloading.
+	if ok, _ := utils.PathIsExist(filepath.Join(config.JavaUnTarFilesPath, version)); !ok {
+		if dirName := installedVendorDir(jdkSystemVendor, strings.TrimPrefix(version, "jdk")); dirName != "" {
+			gprint.PrintInfo(fmt.Sprintf("Use system jdk %s.", dirName))
+			return that.install(dirName, that.download)
+		}
+	}
+	return that.install(version, that.download)
+}
+
+// InstallArchive unarchives a local archive file as InstallVersion does after download.
+func (that *JDKVersion) InstallArchive(version, archive string) (javaHome string) {
+	if vendor, v, ok := parseJDKVendor(version); ok {
+		version = vendorDirName(vendor, v)
+	} else {
+		// versions of archives are like 17, dirs are like jdk17.
+		version = "jdk" + strings.TrimPrefix(version, "jdk")
+	}
+	return that.install(version, func(string) string { return archive })
+}
+
+func (that *JDKVersion) install(version string, getArchive func(version string) string) (javaHome string) {
 	untarfile := filepath.Join(config.JavaUnTarFilesPath, version)
 	if ok, _ := utils.PathIsExist(untarfile); !ok {
-		if tarfile := that.download(version); tarfile != "" {
+		if tarfile := getArchive(version); tarfile != "" {
 			if err := archiver.Unarchive(tarfile, untarfile); err != nil {
 				os.RemoveAll(untarfile)
 				gprint.PrintError(fmt.Sprintf("Unarchive failed: %+v", err))
@@ -318,23 +381,31 @@ func (that *JDKVersion) UseVersion(version string) {
 			}
 		}
 	}
-	if ok, _ := utils.PathIsExist(config.DefaultJavaRoot); ok {
-		os.RemoveAll(config.DefaultJavaRoot)
-	}
+	that.dir = ""
 	that.findDir(untarfile)
 	if that.dir == "" {
 		gprint.PrintError(fmt.Sprintf("Can not find binaries in %s", untarfile))
-		return
+	}
+	return that.dir
+}
+
+func (that *JDKVersion) UseVersion(version string) bool {
+	if that.InstallVersion(version) == "" {
+		return false
+	}
+	if ok, _ := utils.PathIsExist(config.DefaultJavaRoot); ok {
+		os.RemoveAll(config.DefaultJavaRoot)
 	}
 
 	if err := utils.MkSymLink(that.dir, config.DefaultJavaRoot); err != nil {
 		gprint.PrintError(fmt.Sprintf("Create link failed: %+v", err))
-		return
+		return false
 	}
 	if !that.env.DoesEnvExist(utils.SUB_JDK) {
 		that.CheckAndInitEnv()
 	}
 	gprint.PrintSuccess(fmt.Sprintf("Use %s succeeded!", version))
+	return true
 }
 
 func (that *JDKVersion) getCurrent() (version string) {
@@ -357,16 +428,22 @@ func (that *JDKVersion) getCurrent() (version string) {
 func (that *JDKVersion) ShowInstalled() {
 	current := that.getCurrent()
 	dList, _ := os.ReadDir(config.JavaUnTarFilesPath)
+	infos := []*VersionInfo{}
 	for _, d := range dList {
 		if !strings.Contains(d.Name(), "jdk") {
 			continue
 		}
-		if strings.Contains(d.Name(), current) {
-			gprint.Yellow("%s <Current>", d.Name())
-		} else {
-			gprint.Cyan(d.Name())
-		}
+		vendor := vendorOfDir(d.Name())
+		infos = append(infos, &VersionInfo{
+			Version:   d.Name(),
+			Vendor:    vendor,
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+			Installed: true,
+			Current:   that.isCurrent(d.Name(), current),
+		})
 	}
+	printInstalled(infos)
 }
 
 func (that *JDKVersion) removeTarFile(version string) {
@@ -380,7 +457,25 @@ func (that *JDKVersion) removeTarFile(version string) {
 	}
 }
 
+// isCurrent checks the link of DefaultJavaRoot, and the major version for jdks without a vendor.
+func (that *JDKVersion) isCurrent(name, current string) bool {
+	if that.isCurrentDir(name) {
+		return true
+	}
+	return vendorOfDir(name) == "" && current != "" && strings.Contains(name, current)
+}
+
 func (that *JDKVersion) RemoveVersion(version string) {
+	if vendor, v, ok := parseJDKVendor(version); ok {
+		prefix := vendorDirName(vendor, "")
+		for _, name := range installedVersions(config.JavaUnTarFilesPath) {
+			if strings.HasPrefix(name, prefix) && versionMatches(strings.TrimPrefix(name, prefix), v) && !that.isCurrentDir(name) {
+				os.RemoveAll(filepath.Join(config.JavaUnTarFilesPath, name))
+				that.removeTarFile(name)
+			}
+		}
+		return
+	}
 	if !strings.HasPrefix(version, "jdk") {
 		version = fmt.Sprintf("jdk%s", version)
 	}
@@ -395,7 +490,7 @@ func (that *JDKVersion) RemoveUnused() {
 	current := that.getCurrent()
 	dList, _ := os.ReadDir(config.JavaUnTarFilesPath)
 	for _, d := range dList {
-		if !strings.Contains(d.Name(), current) && strings.Contains(d.Name(), "jdk") {
+		if !that.isCurrent(d.Name(), current) && strings.Contains(d.Name(), "jdk") {
 			os.RemoveAll(filepath.Join(config.JavaUnTarFilesPath, d.Name()))
 			that.removeTarFile(d.Name())
 		}
@@ -131,8 +131,20 @@ func (that *GradleVersion) ShowVersions() {
 		vList = append(vList, k)
 	}
 	res := sorts.SortGoVersion(vList)
-	fc := gprint.NewFadeColors(res)
-	fc.Println()
+	if !IsMachineOutput() {
+		fc := gprint.NewFadeColors(res)
+		fc.Println()
+		return
+	}
+	current := utils.ReadVersion(config.GradleRoot)
+	infos := []*VersionInfo{}
+	for _, v := range res {
+		p := that.Versions[v]
+		info := &VersionInfo{Version: v, Url: p.Url, Checksum: p.Checksum, Current: v == current}
+		info.Installed, _ = utils.PathIsExist(filepath.Join(config.GradleUntarFilePath, fmt.Sprintf("gradle-%s", v)))
+		infos = append(infos, info)
+	}
+	PrintVersionInfos(infos)
 }
 
 func (that *GradleVersion) download(version string) (r string) {
@@ -142,8 +154,8 @@ func (that *GradleVersion) download(version string) (r string) {
 		that.fetcher.Timeout = 600 * time.Minute
 		that.fetcher.SetThreadNum(8)
 		fpath := filepath.Join(config.GradleTarFilePath, p.FileName)
-		if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
-			if ok := utils.CheckFile(fpath, "sha256", p.Checksum); ok {
+		if size := cachedDownload(that.fetcher, fpath, "sha256", p.Checksum); size > 0 {
+			if ok := utils.VerifyFile(fpath, "sha256", p.Checksum); ok {
 				return fpath
 			} else {
 				os.RemoveAll(fpath)
@@ -155,10 +167,21 @@ func (that *GradleVersion) download(version string) (r string) {
 	return
 }
 
-func (that *GradleVersion) UseVersion(version string) {
+// InstallVersion downloads and unarchives a version without switching to it.
+// Returns the home dir of the installed version.
+func (that *GradleVersion) InstallVersion(version string) (dir string) {
+	return that.install(version, that.download)
+}
+
+// InstallArchive unarchives a local archive file as InstallVersion does after download.
+func (that *GradleVersion) InstallArchive(version, archive string) (dir string) {
+	return that.install(version, func(string) string { return archive })
+}
+
+func (that *GradleVersion) install(version string, getArchive func(version string) string) (dir string) {
 	untarfile := filepath.Join(config.GradleUntarFilePath, fmt.Sprintf("gradle-%s", version))
 	if ok, _ := utils.PathIsExist(untarfile); !ok {
-		if tarfile := that.download(version); tarfile != "" {
+		if tarfile := getArchive(version); tarfile != "" {
 			if err := archiver.Unarchive(tarfile, untarfile); err != nil {
 				os.RemoveAll(untarfile)
 				gprint.PrintError(fmt.Sprintf("Unarchive failed: %+v", err))
@@ -166,22 +189,28 @@ func (that *GradleVersion) UseVersion(version string) {
 			}
 		}
 	}
-	if ok, _ := utils.PathIsExist(config.GradleRoot); ok {
-		os.RemoveAll(config.GradleRoot)
-	}
 	finder := utils.NewBinaryFinder(untarfile, "bin")
-	dir := finder.String()
+	return finder.String()
+}
+
+func (that *GradleVersion) UseVersion(version string) bool {
+	dir := that.InstallVersion(version)
 	if dir != "" {
+		if ok, _ := utils.PathIsExist(config.GradleRoot); ok {
+			os.RemoveAll(config.GradleRoot)
+		}
 		if err := utils.MkSymLink(dir, config.GradleRoot); err != nil {
 			gprint.PrintError(fmt.Sprintf("Create link failed: %+v", err))
-			return
+			return false
 		}
 		if !that.env.DoesEnvExist(utils.SUB_GRADLE) {
 			that.CheckAndInitEnv()
 		}
 		utils.RecordVersion(version, dir)
 		gprint.PrintSuccess(fmt.Sprintf("Use %s succeeded!", version))
+		return true
 	}
+	return false
 }
 
 func (that *GradleVersion) CheckAndInitEnv() {
@@ -217,16 +246,14 @@ func (that *GradleVersion) ShowInstalled() {
 	if ok, _ := utils.PathIsExist(config.GradleUntarFilePath); ok {
 		current := utils.ReadVersion(config.GradleRoot)
 		dList, _ := os.ReadDir(config.GradleUntarFilePath)
+		infos := []*VersionInfo{}
 		for _, d := range dList {
 			if strings.Contains(d.Name(), "gradle-") {
 				version := strings.Split(d.Name(), "-")[1]
-				if current == version {
-					gprint.Yellow("%s <Current>", version)
-					continue
-				}
-				gprint.Cyan(version)
+				infos = append(infos, &VersionInfo{Version: version, Installed: true, Current: current == version})
 			}
 		}
+		printInstalled(infos)
 	}
 }
 
@@ -97,7 +97,10 @@ func (that *MavenVersion) getSha(p *MavenPackage) (shaCode string) {
 		that.fetcher.Url = p.ChecksumUrl
 		if resp := that.fetcher.Get(); resp != nil {
 			content, _ := io.ReadAll(resp.RawBody())
-			shaCode = string(content)
+			// files may contain the file name after the checksum.
+			if fields := strings.Fields(string(content)); len(fields) > 0 {
+				shaCode = fields[0]
+			}
 		}
 	}
 	return
@@ -111,20 +114,33 @@ func (that *MavenVersion) ShowVersions() {
 	}
 	if len(vList) > 0 {
 		res := sorts.SortGoVersion(vList)
-		fc := gprint.NewFadeColors(res)
-		fc.Println()
+		if !IsMachineOutput() {
+			fc := gprint.NewFadeColors(res)
+			fc.Println()
+			return
+		}
+		current := utils.ReadVersion(config.MavenRoot)
+		infos := []*VersionInfo{}
+		for _, v := range res {
+			info := &VersionInfo{Version: v, Url: that.Versions[v].Url, Current: v == current}
+			info.Installed, _ = utils.PathIsExist(filepath.Join(config.MavenUntarFilePath, fmt.Sprintf("maven-%s", v)))
+			infos = append(infos, info)
+		}
+		PrintVersionInfos(infos)
 	}
 }
 
 func (that *MavenVersion) download(version string) (r string) {
 	that.getVersions()
 	if p, ok := that.Versions[version]; ok {
+		// getSha uses the fetcher, get it before the download url is set.
+		sha := that.getSha(p)
 		that.fetcher.Url = that.Conf.GVCProxy.WrapUrl(p.Url)
 		that.fetcher.Timeout = 900 * time.Minute
 		that.fetcher.SetThreadNum(8)
 		fpath := filepath.Join(config.MavenTarFilePath, p.FileName)
-		if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
-			if ok := utils.CheckFile(fpath, "sha512", that.getSha(p)); ok {
+		if size := cachedDownload(that.fetcher, fpath, "sha512", sha); size > 0 {
+			if ok := utils.VerifyFile(fpath, "sha512", sha); ok {
 				return fpath
 			} else {
 				os.RemoveAll(fpath)
@@ -136,10 +152,21 @@ func (that *MavenVersion) download(version string) (r string) {
 	return
 }
 
-func (that *MavenVersion) UseVersion(version string) {
+// InstallVersion downloads and unarchives a version without switching to it.
+// Returns the home dir of the installed version.
+func (that *MavenVersion) InstallVersion(version string) (dir string) {
+	return that.install(version, that.download)
+}
+
+// InstallArchive unarchives a local archive file as InstallVersion does after download.
+func (that *MavenVersion) InstallArchive(version, archive string) (dir string) {
+	return that.install(version, func(string) string { return archive })
+}
+
+func (that *MavenVersion) install(version string, getArchive func(version string) string) (dir string) {
 	untarfile := filepath.Join(config.MavenUntarFilePath, fmt.Sprintf("maven-%s", version))
 	if ok, _ := utils.PathIsExist(untarfile); !ok {
-		if tarfile := that.download(version); tarfile != "" {
+		if tarfile := getArchive(version); tarfile != "" {
 			if err := archiver.Unarchive(tarfile, untarfile); err != nil {
 				os.RemoveAll(untarfile)
 				gprint.PrintError(fmt.Sprintf("Unarchive failed: %+v", err))
@@ -147,22 +174,28 @@ func (that *MavenVersion) UseVersion(version string) {
 			}
 		}
 	}
-	if ok, _ := utils.PathIsExist(config.MavenRoot); ok {
-		os.RemoveAll(config.MavenRoot)
-	}
 	finder := utils.NewBinaryFinder(untarfile, "bin")
-	dir := finder.String()
+	return finder.String()
+}
+
+func (that *MavenVersion) UseVersion(version string) bool {
+	dir := that.InstallVersion(version)
 	if dir != "" {
+		if ok, _ := utils.PathIsExist(config.MavenRoot); ok {
+			os.RemoveAll(config.MavenRoot)
+		}
 		if err := utils.MkSymLink(dir, config.MavenRoot); err != nil {
 			gprint.PrintError(fmt.Sprintf("Create link failed: %+v", err))
-			return
+			return false
 		}
 		if !that.env.DoesEnvExist(utils.SUB_MAVEN) {
 			that.CheckAndInitEnv()
 		}
 		utils.RecordVersion(version, dir)
 		gprint.PrintSuccess(fmt.Sprintf("Use %s succeeded!", version))
+		return true
 	}
+	return false
 }
 
 func (that *MavenVersion) CheckAndInitEnv() {
@@ -179,31 +212,27 @@ func (that *MavenVersion) CheckAndInitEnv() {
 	}
 }
 
+// GenSettingsFile writes the local repository and the mirror in use to settings.xml.
 func (that *MavenVersion) GenSettingsFile() {
-	sf := filepath.Join(config.MavenSettingsFileDir, "settings.xml")
-	osf := filepath.Join(config.MavenSettingsFileDir, "settings.xml.origin")
-	if ok, _ := utils.PathIsExist(config.MavenSettingsFileDir); ok {
-		if ok1, _ := utils.PathIsExist(osf); !ok1 {
-			utils.CopyFile(sf, osf)
-		}
-		os.WriteFile(sf, []byte(config.MavenSettings), 0644)
+	if err := that.writeSettings(); err != nil {
+		gprint.PrintError("%+v", err)
+		return
 	}
+	that.Conf.Restore()
 }
 
 func (that *MavenVersion) ShowInstalled() {
 	if ok, _ := utils.PathIsExist(config.MavenUntarFilePath); ok {
 		current := utils.ReadVersion(config.MavenRoot)
 		dList, _ := os.ReadDir(config.MavenUntarFilePath)
+		infos := []*VersionInfo{}
 		for _, d := range dList {
 			if strings.Contains(d.Name(), "maven-") {
 				version := strings.Split(d.Name(), "-")[1]
-				if current == version {
-					gprint.Yellow("%s <Current>", version)
-					continue
-				}
-				gprint.Cyan(version)
+				infos = append(infos, &VersionInfo{Version: version, Installed: true, Current: current == version})
 			}
 		}
+		printInstalled(infos)
 	}
 }
 
@@ -0,0 +1,320 @@
+package vctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils/sorts"
+)
+
+/*
+Vendor JDKs, like temurin@21 or zulu@17.0.9.
+
+Temurin is found with the Adoptium API, other vendors with the Foojay Disco API.
+Installed vendor JDKs are kept in JavaUnTarFilesPath as <vendor>-jdk<version>, like temurin-jdk21.0.1+12.
+JDKs adopted from the system are linked as system-jdk<version>.
+*/
+const jdkSystemVendor = "system"
+
+var jdkVendorAliases = map[string]string{
+	"temurin":           "temurin",
+	"adoptium":          "temurin",
+	"zulu":              "zulu",
+	"corretto":          "corretto",
+	"graalvm":           "graalvm",
+	"graalvm-ce":        "graalvm",
+	"graalvm_community": "graalvm",
+	jdkSystemVendor:     jdkSystemVendor,
+}
+
+// distributions in the Foojay Disco API.
+var jdkFoojayDistributions = map[string]string{
+	"temurin":  "temurin",
+	"zulu":     "zulu",
+	"corretto": "corretto",
+	"graalvm":  "graalvm_community",
+}
+
+var jdkArchList = map[string]string{
+	"amd64": "x64",
+	"arm64": "aarch64",
+	"386":   "x86",
+}
+
+// parseJDKVendor parses versions like temurin@21, ok is false for versions without a vendor.
+func parseJDKVendor(s string) (vendor, version string, ok bool) {
+	sList := strings.SplitN(strings.TrimSpace(s), "@", 2)
+	if len(sList) != 2 {
+		return "", s, false
+	}
+	if vendor, ok = jdkVendorAliases[strings.ToLower(sList[0])]; !ok {
+		return "", s, false
+	}
+	return vendor, strings.TrimPrefix(strings.TrimPrefix(sList[1], "jdk"), "-"), true
+}
+
+func vendorDirName(vendor, version string) string {
+	return fmt.Sprintf("%s-jdk%s", vendor, version)
+}
+
+// vendorOfDir returns the vendor of a versions dir, empty for jdks from injdk.cn or oracle.com.
+func vendorOfDir(name string) string {
+	if idx := strings.Index(name, "-jdk"); idx > 0 {
+		if jdkVendorAliases[name[:idx]] == name[:idx] {
+			return name[:idx]
+		}
+	}
+	return ""
+}
+
+// versionMatches reports whether a full version like 21.0.1+12 matches 21, 21.0 or 21.0.1.
+func versionMatches(full, version string) bool {
+	return full == version || strings.HasPrefix(full, version+".") || strings.HasPrefix(full, version+"+")
+}
+
+func jdkMajorVersion(version string) string {
+	return strings.Split(strings.Split(version, "+")[0], ".")[0]
+}
+
+func (that *JDKVersion) getJSON(dUrl string, result interface{}) error {
+	that.fetcher.Url = dUrl
+	that.fetcher.Timeout = 30 * time.Second
+	resp := that.fetcher.Get()
+	if resp == nil || resp.StatusCode() != 200 {
+		return fmt.Errorf("request %s failed", dUrl)
+	}
+	return json.Unmarshal(resp.Body(), result)
+}
+
+type adoptiumRelease struct {
+	Binaries []struct {
+		ImageType string `json:"image_type"`
+		Package   struct {
+			Checksum string `json:"checksum"`
+			Link     string `json:"link"`
+			Name     string `json:"name"`
+			Size     int64  `json:"size"`
+		} `json:"package"`
+	} `json:"binaries"`
+	VersionData struct {
+		Semver string `json:"semver"`
+	} `json:"version_data"`
+}
+
+// adoptiumPackage finds temurin jdks with the Adoptium API.
+func (that *JDKVersion) adoptiumPackage(version string) (p *JDKPackage, err error) {
+	osName := runtime.GOOS
+	if osName == "darwin" {
+		osName = "mac"
+	}
+	query := url.Values{}
+	query.Set("architecture", jdkArchList[runtime.GOARCH])
+	query.Set("image_type", "jdk")
+	query.Set("jvm_impl", "hotspot")
+	query.Set("os", osName)
+	query.Set("vendor", "eclipse")
+	query.Set("sort_order", "DESC")
+	query.Set("page_size", "50")
+	dUrl := fmt.Sprintf("%s/assets/feature_releases/%s/ga?%s",
+		strings.TrimSuffix(that.Conf.Java.AdoptiumUrl, "/"), jdkMajorVersion(version), query.Encode())
+
+	releases := []*adoptiumRelease{}
+	if err = that.getJSON(dUrl, &releases); err != nil {
+		return
+	}
+	for _, r := range releases {
+		if !versionMatches(r.VersionData.Semver, version) {
+			continue
+		}
+		for _, b := range r.Binaries {
+			if b.ImageType != "jdk" || that.GetFileSuffix(b.Package.Name) == "" {
+				continue
+			}
+			return &JDKPackage{
+				Url:       b.Package.Link,
+				FileName:  b.Package.Name,
+				OS:        runtime.GOOS,
+				Arch:      runtime.GOARCH,
+				Size:      fmt.Sprintf("%d", b.Package.Size),
+				Checksum:  b.Package.Checksum,
+				CheckType: "sha256",
+				Vendor:    "temurin",
+				Version:   r.VersionData.Semver,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot find temurin %s for %s/%s", version, runtime.GOOS, runtime.GOARCH)
+}
+
+type foojayPackage struct {
+	ArchiveType string `json:"archive_type"`
+	JavaVersion string `json:"java_version"`
+	FileName    string `json:"filename"`
+	LibCType    string `json:"lib_c_type"`
+	Links       struct {
+		PkgInfoUri string `json:"pkg_info_uri"`
+	} `json:"links"`
+	Size int64 `json:"size"`
+}
+
+type foojayPackageInfo struct {
+	FileName          string `json:"filename"`
+	DirectDownloadUri string `json:"direct_download_uri"`
+	Checksum          string `json:"checksum"`
+	ChecksumType      string `json:"checksum_type"`
+	ChecksumUri       string `json:"checksum_uri"`
+}
+
+// checksum returns a checksum supported by utils.HashFile,
+// the sha256 checksum from checksum_uri is used when checksum is missing or of other types.
+func (that *foojayPackageInfo) checksum(fileName string) (cType, cSum string, err error) {
+	cType, cSum = strings.ToLower(that.ChecksumType), strings.ToLower(that.Checksum)
+	switch cType {
+	case "sha1", "sha256", "sha512":
+		if cSum != "" {
+			return
+		}
+	}
+	if that.ChecksumUri == "" {
+		return "", "", fmt.Errorf("unsupported checksum type %q of %s", that.ChecksumType, fileName)
+	}
+	if cSum = strings.ToLower(findChecksumLine(getText(that.ChecksumUri), fileName)); len(cSum) != 64 {
+		return "", "", fmt.Errorf("cannot find sha256 checksum of %s in %s", fileName, that.ChecksumUri)
+	}
+	return "sha256", cSum, nil
+}
+
+// foojayPackage finds jdks with the Foojay Disco API.
+func (that *JDKVersion) foojayPackage(vendor, version string) (p *JDKPackage, err error) {
+	osName := runtime.GOOS
+	if osName == "darwin" {
+		osName = "macos"
+	}
+	query := url.Values{}
+	query.Set("version", version)
+	query.Set("distribution", jdkFoojayDistributions[vendor])
+	query.Set("architecture", jdkArchList[runtime.GOARCH])
+	query.Set("operating_system", osName)
+	query["archive_type"] = []string{"tar.gz", "zip"}
+	query.Set("package_type", "jdk")
+	query.Set("javafx_bundled", "false")
+	query.Set("release_status", "ga")
+	if !strings.ContainsAny(version, ".+") {
+		query.Set("latest", "available")
+	}
+	dUrl := fmt.Sprintf("%s/packages?%s", strings.TrimSuffix(that.Conf.Java.FoojayUrl, "/"), query.Encode())
+
+	pkgs := struct {
+		Result []*foojayPackage `json:"result"`
+	}{}
+	if err = that.getJSON(dUrl, &pkgs); err != nil {
+		return
+	}
+	var found *foojayPackage
+	for _, fp := range pkgs.Result {
+		if !versionMatches(fp.JavaVersion, version) || fp.LibCType == "musl" || that.GetFileSuffix(fp.FileName) == "" {
+			continue
+		}
+		if found == nil || sorts.CompareGoVersion(strings.Split(fp.JavaVersion, "+")[0], strings.Split(found.JavaVersion, "+")[0]) > 0 {
+			found = fp
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("cannot find %s %s for %s/%s", vendor, version, runtime.GOOS, runtime.GOARCH)
+	}
+
+	infos := struct {
+		Result []*foojayPackageInfo `json:"result"`
+	}{}
+	if err = that.getJSON(found.Links.PkgInfoUri, &infos); err != nil {
+		return
+	}
+	if len(infos.Result) == 0 {
+		return nil, fmt.Errorf("cannot find download info of %s", found.FileName)
+	}
+	info := infos.Result[0]
+	cType, cSum, err := info.checksum(found.FileName)
+	if err != nil {
+		return nil, err
+	}
+	return &JDKPackage{
+		Url:       info.DirectDownloadUri,
+		FileName:  found.FileName,
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Size:      fmt.Sprintf("%d", found.Size),
+		Checksum:  cSum,
+		CheckType: cType,
+		Vendor:    vendor,
+		Version:   found.JavaVersion,
+	}, nil
+}
+
+func (that *JDKVersion) vendorPackage(vendor, version string) (*JDKPackage, error) {
+	if that.Conf.Java.AdoptiumUrl == "" || that.Conf.Java.FoojayUrl == "" {
+		that.Conf.Java.Reset()
+	}
+	if vendor == "temurin" {
+		return that.adoptiumPackage(version)
+	}
+	return that.foojayPackage(vendor, version)
+}
+
+// installedVendorDir finds the latest installed version of a vendor, for offline use.
+func installedVendorDir(vendor, version string) (dirName string) {
+	prefix := vendorDirName(vendor, "")
+	latest := ""
+	for _, name := range installedVersions(config.JavaUnTarFilesPath) {
+		v := strings.TrimPrefix(name, prefix)
+		if !strings.HasPrefix(name, prefix) || !versionMatches(v, version) {
+			continue
+		}
+		if latest == "" || sorts.CompareGoVersion(strings.Split(v, "+")[0], strings.Split(latest, "+")[0]) > 0 {
+			latest, dirName = v, name
+		}
+	}
+	return
+}
+
+// resolveVendor resolves a vendor version to a versions dir, p is nil when only an installed version is found.
+func (that *JDKVersion) resolveVendor(vendor, version string) (dirName string, p *JDKPackage) {
+	if vendor == jdkSystemVendor {
+		if dirName = installedVendorDir(vendor, version); dirName == "" {
+			gprint.PrintError(fmt.Sprintf("Cannot find system jdk %s, run 'gvc java adopt' first.", version))
+		}
+		return dirName, nil
+	}
+	p, err := that.vendorPackage(vendor, version)
+	if err == nil {
+		dirName = vendorDirName(vendor, p.Version)
+		gprint.PrintInfo(fmt.Sprintf("Resolved %s@%s to %s.", vendor, version, dirName))
+		return
+	}
+	if dirName = installedVendorDir(vendor, version); dirName != "" {
+		gprint.PrintWarning(fmt.Sprintf("%+v, use installed %s.", err, dirName))
+		return dirName, nil
+	}
+	gprint.PrintError("%+v", err)
+	return "", nil
+}
+
+// isCurrentDir reports whether DefaultJavaRoot links to a dir in the versions dir.
+func (that *JDKVersion) isCurrentDir(name string) bool {
+	current, err := filepath.EvalSymlinks(config.DefaultJavaRoot)
+	if err != nil {
+		return false
+	}
+	vDir, err := filepath.EvalSymlinks(filepath.Join(config.JavaUnTarFilesPath, name))
+	if err != nil {
+		return false
+	}
+	return current == vDir || strings.HasPrefix(current, vDir+string(os.PathSeparator))
+}
@@ -56,16 +56,16 @@ func (that *JuliaVersion) getJson() {
 	itemList := selector.NewItemList()
 	itemList.Add("from mirrors.tuna.tsinghua.edu.cn", that.Conf.Julia.VersionUrl)
 	itemList.Add("from julialang.org", that.Conf.Julia.VersionUrlOfficial)
-	sel := selector.NewSelector(
+	source := rememberAnswer(that.Conf, &that.Conf.Julia.DownloadSource, utils.Answers.Source)
+	val := selectOne(
 		itemList,
+		source,
+		"source",
 		selector.WithTitle("Choose a download resource:"),
 		selector.WithEnbleInfinite(true),
-		selector.WidthEnableMulti(false),
 		selector.WithWidth(40),
 		selector.WithHeight(10),
 	)
-	sel.Run()
-	val := sel.Value()[0]
 	that.fetcher.Url = val.(string)
 
 	if !utils.VerifyUrls(that.fetcher.Url) {
@@ -124,8 +124,23 @@ func (that *JuliaVersion) ShowVersions() {
 		vList = append(vList, v)
 	}
 	res := sorts.SortGoVersion(vList)
-	fc := gprint.NewFadeColors(res)
-	fc.Println()
+	if !IsMachineOutput() {
+		fc := gprint.NewFadeColors(res)
+		fc.Println()
+		return
+	}
+	current := utils.ReadVersion(config.JuliaRootDir)
+	infos := []*VersionInfo{}
+	for _, v := range res {
+		info := &VersionInfo{Version: v, OS: runtime.GOOS, Arch: runtime.GOARCH, Current: v == current}
+		if p := that.findPackage(v); p != nil {
+			info.Url = p.Url
+			info.Checksum = p.Checksum
+		}
+		info.Installed, _ = utils.PathIsExist(filepath.Join(config.JuliaUntarFilePath, v))
+		infos = append(infos, info)
+	}
+	PrintVersionInfos(infos)
 }
 
 func (that *JuliaVersion) findPackage(version string) *JuliaPackage {
@@ -159,15 +174,11 @@ func (that *JuliaVersion) download(version string) (r string) {
 		that.fetcher.Timeout = 100 * time.Minute
 		that.fetcher.SetThreadNum(8)
 		fpath := filepath.Join(config.JuliaTarFilePath, p.FileName)
-		if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
-			if p.Checksum != "" {
-				if ok := utils.CheckFile(fpath, "sha256", p.Checksum); ok {
-					return fpath
-				} else {
-					os.RemoveAll(fpath)
-				}
-			} else {
+		if size := cachedDownload(that.fetcher, fpath, "sha256", p.Checksum); size > 0 {
+			if ok := utils.VerifyFile(fpath, "sha256", p.Checksum); ok {
 				return fpath
+			} else {
+				os.RemoveAll(fpath)
 			}
 		} else {
 			os.RemoveAll(fpath)
@@ -193,10 +204,21 @@ func (that *JuliaVersion) CheckAndInitEnv() {
 	}
 }
 
-func (that *JuliaVersion) UseVersion(version string) {
+// InstallVersion downloads and unarchives a version without switching to it.
+// Returns the home dir of the installed version.
+func (that *JuliaVersion) InstallVersion(version string) (dir string) {
+	return that.install(version, that.download)
+}
+
+// InstallArchive unarchives a local archive file as InstallVersion does after download.
+func (that *JuliaVersion) InstallArchive(version, archive string) (dir string) {
+	return that.install(version, func(string) string { return archive })
+}
+
+func (that *JuliaVersion) install(version string, getArchive func(version string) string) (dir string) {
 	untarfile := filepath.Join(config.JuliaUntarFilePath, version)
 	if ok, _ := utils.PathIsExist(untarfile); !ok {
-		if tarfile := that.download(version); tarfile != "" {
+		if tarfile := getArchive(version); tarfile != "" {
 			if err := archiver.Unarchive(tarfile, untarfile); err != nil {
 				os.RemoveAll(untarfile)
 				gprint.PrintError(fmt.Sprintf("Unarchive failed: %+v", err))
@@ -204,37 +226,46 @@ func (that *JuliaVersion) UseVersion(version string) {
 			}
 		}
 	}
-	if ok, _ := utils.PathIsExist(config.JuliaRootDir); ok {
-		os.RemoveAll(config.JuliaRootDir)
-	}
 	finder := utils.NewBinaryFinder(untarfile, "bin")
-	dir := finder.String()
+	return finder.String()
+}
+
+func (that *JuliaVersion) UseVersion(version string) bool {
+	dir := that.InstallVersion(version)
 	if dir != "" {
+		if ok, _ := utils.PathIsExist(config.JuliaRootDir); ok {
+			os.RemoveAll(config.JuliaRootDir)
+		}
 		if err := utils.MkSymLink(dir, config.JuliaRootDir); err != nil {
 			gprint.PrintError(fmt.Sprintf("Create link failed: %+v", err))
-			return
+			return false
 		}
 		if !that.env.DoesEnvExist(utils.SUB_JULIA) {
 			that.CheckAndInitEnv()
 		}
 		utils.RecordVersion(version, dir)
 		gprint.PrintSuccess(fmt.Sprintf("Use %s succeeded!", version))
+		return true
 	}
+	return false
 }
 
 func (that *JuliaVersion) ShowInstalled() {
 	current := utils.ReadVersion(config.JuliaRootDir)
 	dList, _ := os.ReadDir(config.JuliaUntarFilePath)
+	infos := []*VersionInfo{}
 	for _, d := range dList {
 		if d.IsDir() {
-			switch d.Name() {
-			case current:
-				gprint.Yellow("%s <Current>", d.Name())
-			default:
-				gprint.Cyan(d.Name())
-			}
+			infos = append(infos, &VersionInfo{
+				Version:   d.Name(),
+				OS:        runtime.GOOS,
+				Arch:      runtime.GOARCH,
+				Installed: true,
+				Current:   d.Name() == current,
+			})
 		}
 	}
+	printInstalled(infos)
 }
 
 func (that *JuliaVersion) removeTarFile(version string) {
@@ -34,8 +34,8 @@ func NewVlang() (vl *Vlang) {
 }
 
 func (that *Vlang) download(force bool) string {
-	that.fetcher.Url = that.Conf.Vlang.VlangUrls[runtime.GOOS]
-	that.fetcher.Url = that.Conf.GVCProxy.WrapUrl(that.fetcher.Url)
+	assetUrl := that.Conf.Vlang.VlangUrls[runtime.GOOS]
+	that.fetcher.Url = that.Conf.GVCProxy.WrapUrl(assetUrl)
 
 	if that.fetcher.Url != "" {
 		fpath := filepath.Join(config.VlangFilesDir, "vlang.zip")
@@ -45,7 +45,7 @@ func (that *Vlang) download(force bool) string {
 		that.fetcher.Timeout = 20 * time.Minute
 		that.fetcher.SetThreadNum(3)
 		if ok, _ := utils.PathIsExist(fpath); !ok || force {
-			if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
+			if size := that.fetcher.GetAndSaveFile(fpath); size > 0 && verifyGithubAsset(fpath, assetUrl) {
 				return fpath
 			} else {
 				os.RemoveAll(fpath)
@@ -93,13 +93,18 @@ func (that *Vlang) InstallVAnalyzerForVscode() {
 	if key == utils.MacOS {
 		key = fmt.Sprintf("%s_%s", key, runtime.GOARCH)
 	}
-	that.fetcher.Url = that.Conf.Vlang.AnalyzerUrls[key]
-	that.fetcher.Url = that.Conf.GVCProxy.WrapUrl(that.fetcher.Url)
+	assetUrl := that.Conf.Vlang.AnalyzerUrls[key]
+	that.fetcher.Url = that.Conf.GVCProxy.WrapUrl(assetUrl)
 	if that.fetcher.Url != "" {
 		fpath := filepath.Join(config.VlangFilesDir, "analyzer.zip")
 		that.fetcher.Timeout = 20 * time.Minute
 		that.fetcher.SetThreadNum(3)
 		if ok, _ := utils.PathIsExist(fpath); !ok {
+			cType, cSum, ok := requireGithubDigest(assetUrl)
+			if !ok {
+				return
+			}
+			that.fetcher.SetCheckSum(cSum, cType)
 			if err := that.fetcher.DownloadAndDecompress(fpath, config.VlangFilesDir, true); err == nil {
 				gprint.PrintSuccess(fpath)
 			} else {
@@ -0,0 +1,490 @@
+package vctrl
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+/*
+Switchable maven mirrors in settings.xml.
+
+settings.xml is scanned with xml.Decoder for offsets of elements, only localRepository and
+mirrors, servers and profiles of gvc are rewritten, other bytes like the root element,
+comments and attributes are kept as they are.
+*/
+const (
+	mavenSettingsHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<settings xmlns="http://maven.apache.org/SETTINGS/1.2.0"
+          xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+          xsi:schemaLocation="http://maven.apache.org/SETTINGS/1.2.0 https://maven.apache.org/xsd/settings-1.2.0.xsd">
+</settings>
+`
+	mavenProfilePrefix = "gvc-"
+)
+
+// order of top-level elements in settings.xml.
+var mavenSettingsOrder = []string{
+	"localRepository",
+	"interactiveMode",
+	"offline",
+	"pluginGroups",
+	"servers",
+	"mirrors",
+	"proxies",
+	"profiles",
+	"activeProfiles",
+}
+
+// mavenElement is an element with byte offsets in settings.xml.
+type mavenElement struct {
+	Name       string
+	Text       string
+	Start      int
+	InnerStart int
+	InnerEnd   int
+	End        int
+	Children   []*mavenElement
+}
+
+// text returns the text of a child element, like the id of a mirror.
+func (that *mavenElement) text(name string) string {
+	for _, e := range that.Children {
+		if e.Name == name {
+			return e.Text
+		}
+	}
+	return ""
+}
+
+// selfClosing reports whether the element is like <mirrors/>.
+func (that *mavenElement) selfClosing() bool {
+	return that.InnerStart == that.End
+}
+
+// mavenEdit replaces content[Start:End] with Text.
+type mavenEdit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+type mavenSettings struct {
+	content []byte
+	root    *mavenElement
+	edits   []*mavenEdit
+}
+
+// parseMavenElements scans elements of content with their offsets, and returns the root element.
+func parseMavenElements(content []byte) (root *mavenElement, err error) {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	stack := []*mavenElement{}
+	for {
+		start := int(d.InputOffset())
+		token, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		offset := int(d.InputOffset())
+		switch t := token.(type) {
+		case xml.StartElement:
+			e := &mavenElement{Name: t.Name.Local, Start: start, InnerStart: offset}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, e)
+			} else if root == nil {
+				root = e
+			}
+			stack = append(stack, e)
+		case xml.EndElement:
+			e := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			// self-closing elements end where they start.
+			e.InnerEnd, e.End = start, offset
+			if e.End == e.InnerStart {
+				e.InnerEnd = e.InnerStart
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += strings.TrimSpace(string(t))
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no root element")
+	}
+	return root, nil
+}
+
+func readMavenSettings(fpath string) (*mavenSettings, error) {
+	content, err := os.ReadFile(fpath)
+	if os.IsNotExist(err) {
+		content = []byte(mavenSettingsHeader)
+	} else if err != nil {
+		return nil, err
+	}
+	root, err := parseMavenElements(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %+v", fpath, err)
+	}
+	if root.Name != "settings" {
+		return nil, fmt.Errorf("%s is not a maven settings file", fpath)
+	}
+	if root.selfClosing() {
+		// <settings/> is opened, so that elements can be added.
+		openTag := strings.TrimSuffix(strings.TrimRight(strings.TrimSuffix(string(content[root.Start:root.End]), "/>"), " \t\r\n"), "/")
+		content = append(append([]byte{}, content[:root.Start]...), append([]byte(openTag+">\n</settings>"), content[root.End:]...)...)
+		if root, err = parseMavenElements(content); err != nil {
+			return nil, fmt.Errorf("parse %s failed: %+v", fpath, err)
+		}
+	}
+	return &mavenSettings{content: content, root: root}, nil
+}
+
+func mavenSettingsIndex(name string) int {
+	for idx, n := range mavenSettingsOrder {
+		if n == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+func isXMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// trimSpaceBefore moves offset back over whitespace, but not before min.
+func (that *mavenSettings) trimSpaceBefore(offset, min int) int {
+	for offset > min && isXMLSpace(that.content[offset-1]) {
+		offset--
+	}
+	return offset
+}
+
+func (that *mavenSettings) edit(start, end int, text string) {
+	that.edits = append(that.edits, &mavenEdit{Start: start, End: end, Text: text})
+}
+
+// element finds a top-level element, nil if it does not exist.
+func (that *mavenSettings) element(name string) *mavenElement {
+	for _, e := range that.root.Children {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// insert adds a new top-level element in the order of settings.xml.
+func (that *mavenSettings) insert(name, inner string) {
+	text := fmt.Sprintf("<%s>%s</%s>", name, inner, name)
+	for _, e := range that.root.Children {
+		if mavenSettingsIndex(e.Name) > mavenSettingsIndex(name) {
+			that.edit(e.Start, e.Start, text+"\n  ")
+			return
+		}
+	}
+	offset := that.trimSpaceBefore(that.root.InnerEnd, that.root.InnerStart)
+	that.edit(offset, offset, "\n  "+text)
+}
+
+// setInner replaces the content of a top-level element, the element is added if it does not exist.
+func (that *mavenSettings) setInner(name, inner string) {
+	e := that.element(name)
+	switch {
+	case e == nil:
+		that.insert(name, inner)
+	case e.selfClosing():
+		openTag := strings.TrimRight(strings.TrimSuffix(string(that.content[e.Start:e.End]), "/>"), " \t\r\n")
+		that.edit(e.Start, e.End, fmt.Sprintf("%s>%s</%s>", openTag, inner, name))
+	default:
+		that.edit(e.InnerStart, e.InnerEnd, inner)
+	}
+}
+
+// replace replaces children of gvc in a top-level element with entries, other children and comments are kept.
+func (that *mavenSettings) replace(name string, isGVC func(e *mavenElement) bool, entries ...interface{}) error {
+	lines := []string{}
+	for _, entry := range entries {
+		content, err := xml.MarshalIndent(entry, "    ", "  ")
+		if err != nil {
+			return err
+		}
+		lines = append(lines, string(content))
+	}
+	added := ""
+	if len(lines) > 0 {
+		added = "\n" + strings.Join(lines, "\n")
+	}
+
+	e := that.element(name)
+	if e == nil || e.selfClosing() {
+		if added != "" {
+			that.setInner(name, added+"\n  ")
+		}
+		return nil
+	}
+	for _, c := range e.Children {
+		if isGVC(c) {
+			that.edit(that.trimSpaceBefore(c.Start, e.InnerStart), c.End, "")
+		}
+	}
+	offset := that.trimSpaceBefore(e.InnerEnd, e.InnerStart)
+	if offset == e.InnerEnd {
+		// no whitespace before the end tag.
+		added += "\n  "
+	}
+	that.edit(offset, offset, added)
+	return nil
+}
+
+// Bytes applies edits to the original content.
+func (that *mavenSettings) Bytes() []byte {
+	sort.SliceStable(that.edits, func(i, j int) bool {
+		return that.edits[i].Start < that.edits[j].Start
+	})
+	buf := bytes.NewBuffer(nil)
+	pos := 0
+	for _, e := range that.edits {
+		buf.Write(that.content[pos:e.Start])
+		buf.WriteString(e.Text)
+		pos = e.End
+	}
+	buf.Write(that.content[pos:])
+	return buf.Bytes()
+}
+
+type mavenMirrorXML struct {
+	XMLName  xml.Name `xml:"mirror"`
+	Id       string   `xml:"id"`
+	Name     string   `xml:"name"`
+	MirrorOf string   `xml:"mirrorOf"`
+	Url      string   `xml:"url"`
+}
+
+type mavenServerXML struct {
+	XMLName  xml.Name `xml:"server"`
+	Id       string   `xml:"id"`
+	Username string   `xml:"username,omitempty"`
+	Password string   `xml:"password,omitempty"`
+}
+
+type mavenEnabledXML struct {
+	Enabled bool `xml:"enabled"`
+}
+
+type mavenRepositoryXML struct {
+	Id        string           `xml:"id"`
+	Url       string           `xml:"url"`
+	Releases  *mavenEnabledXML `xml:"releases"`
+	Snapshots *mavenEnabledXML `xml:"snapshots"`
+}
+
+type mavenProfileXML struct {
+	XMLName            xml.Name              `xml:"profile"`
+	Id                 string                `xml:"id"`
+	Repositories       []*mavenRepositoryXML `xml:"repositories>repository"`
+	PluginRepositories []*mavenRepositoryXML `xml:"pluginRepositories>pluginRepository"`
+}
+
+type mavenActiveProfileXML struct {
+	XMLName xml.Name `xml:"activeProfile"`
+	Id      string   `xml:",chardata"`
+}
+
+// applyMirror replaces mirrors, servers and profiles of gvc with the mirror named name.
+func (that *mavenSettings) applyMirror(mirrors map[string]*config.MavenMirror, name string) error {
+	m := mirrors[name]
+	mirrorIds, serverIds := map[string]bool{}, map[string]bool{}
+	for _, id := range config.MavenLegacyMirrorIds {
+		mirrorIds[id] = true
+	}
+	for _, mm := range mirrors {
+		mirrorIds[mm.Id] = true
+		// servers without credentials are not written, servers of users with the same id are kept.
+		if mm.Username != "" || mm.Password != "" {
+			serverIds[mm.Id] = true
+		}
+	}
+	isGVCProfile := func(e *mavenElement) bool {
+		id := e.text("id")
+		if e.Name == "activeProfile" {
+			id = e.Text
+		}
+		return strings.HasPrefix(id, mavenProfilePrefix)
+	}
+
+	localRepo := &strings.Builder{}
+	xml.EscapeText(localRepo, []byte(config.JavaLocalRepoPath))
+	that.setInner("localRepository", localRepo.String())
+	servers := []interface{}{}
+	if m.Username != "" || m.Password != "" {
+		servers = append(servers, &mavenServerXML{Id: m.Id, Username: m.Username, Password: m.Password})
+	}
+	if err := that.replace("servers", func(e *mavenElement) bool { return serverIds[e.text("id")] }, servers...); err != nil {
+		return err
+	}
+	mirror := &mavenMirrorXML{Id: m.Id, Name: name, MirrorOf: m.MirrorOf, Url: m.Url}
+	if err := that.replace("mirrors", func(e *mavenElement) bool { return mirrorIds[e.text("id")] }, mirror); err != nil {
+		return err
+	}
+	repo := &mavenRepositoryXML{
+		Id:        m.Id,
+		Url:       m.Url,
+		Releases:  &mavenEnabledXML{Enabled: true},
+		Snapshots: &mavenEnabledXML{Enabled: m.Snapshots},
+	}
+	profile := &mavenProfileXML{
+		Id:                 mavenProfilePrefix + name,
+		Repositories:       []*mavenRepositoryXML{repo},
+		PluginRepositories: []*mavenRepositoryXML{repo},
+	}
+	if err := that.replace("profiles", isGVCProfile, profile); err != nil {
+		return err
+	}
+	return that.replace("activeProfiles", isGVCProfile, &mavenActiveProfileXML{Id: profile.Id})
+}
+
+func (that *MavenVersion) mirrors() map[string]*config.MavenMirror {
+	if len(that.Conf.Maven.Mirrors) == 0 {
+		// configs of older versions have no mirrors.
+		cnf := config.NewMavenConf()
+		cnf.Reset()
+		that.Conf.Maven.Mirrors, that.Conf.Maven.Mirror = cnf.Mirrors, cnf.Mirror
+	}
+	return that.Conf.Maven.Mirrors
+}
+
+// writeSettings writes the mirror in use to settings.xml of the maven in use.
+func (that *MavenVersion) writeSettings() error {
+	mirrors := that.mirrors()
+	if _, ok := mirrors[that.Conf.Maven.Mirror]; !ok {
+		return fmt.Errorf("cannot find maven mirror: %s", that.Conf.Maven.Mirror)
+	}
+	if ok, _ := utils.PathIsExist(config.MavenSettingsFileDir); !ok {
+		return fmt.Errorf("no maven version in use")
+	}
+	sf := filepath.Join(config.MavenSettingsFileDir, "settings.xml")
+	osf := filepath.Join(config.MavenSettingsFileDir, "settings.xml.origin")
+	if ok, _ := utils.PathIsExist(osf); !ok {
+		utils.CopyFile(sf, osf)
+	}
+	s, err := readMavenSettings(sf)
+	if err != nil {
+		return err
+	}
+	if err = s.applyMirror(mirrors, that.Conf.Maven.Mirror); err != nil {
+		return err
+	}
+	return os.WriteFile(sf, s.Bytes(), 0644)
+}
+
+type MavenMirrorInfo struct {
+	Name     string `json:"name"`
+	Id       string `json:"id"`
+	Url      string `json:"url"`
+	MirrorOf string `json:"mirror_of"`
+	Current  bool   `json:"current"`
+}
+
+// ShowMirrors lists maven mirrors in the config.
+func (that *MavenVersion) ShowMirrors() {
+	mirrors := that.mirrors()
+	iList := []*MavenMirrorInfo{}
+	for name, m := range mirrors {
+		iList = append(iList, &MavenMirrorInfo{
+			Name:     name,
+			Id:       m.Id,
+			Url:      m.Url,
+			MirrorOf: m.MirrorOf,
+			Current:  name == that.Conf.Maven.Mirror,
+		})
+	}
+	sort.Slice(iList, func(i, j int) bool {
+		return iList[i].Name < iList[j].Name
+	})
+	if OutputFormat == OutputJSON {
+		content, _ := json.MarshalIndent(iList, "", "  ")
+		fmt.Println(string(content))
+		return
+	}
+	for _, m := range iList {
+		if OutputFormat == OutputPlain {
+			fmt.Println(strings.Join([]string{m.Name, m.Id, m.Url, m.MirrorOf, fmt.Sprintf("%v", m.Current)}, "\t"))
+			continue
+		}
+		if m.Current {
+			gprint.Green("* %-12s%s  (%s)", m.Name, m.Url, m.MirrorOf)
+		} else {
+			gprint.Cyan("  %-12s%s  (%s)", m.Name, m.Url, m.MirrorOf)
+		}
+	}
+}
+
+// AddMirror adds a mirror to the config, mirrors with the same name are replaced.
+func (that *MavenVersion) AddMirror(name string, m *config.MavenMirror) error {
+	if name == "" || strings.ContainsAny(name, ". ") {
+		return fmt.Errorf("invalid mirror name: %q", name)
+	}
+	if m.Url == "" {
+		return fmt.Errorf("url of mirror %s is empty", name)
+	}
+	if m.Id == "" {
+		m.Id = name
+	}
+	if m.MirrorOf == "" {
+		m.MirrorOf = "*"
+	}
+	that.mirrors()[name] = m
+	that.Conf.Restore()
+	if name == that.Conf.Maven.Mirror {
+		if err := that.writeSettings(); err != nil {
+			gprint.PrintWarning(fmt.Sprintf("Update settings.xml failed: %+v", err))
+		}
+	}
+	gprint.PrintSuccess(fmt.Sprintf("Added maven mirror %s.", name))
+	return nil
+}
+
+// UseMirror switches settings.xml to a mirror.
+func (that *MavenVersion) UseMirror(name string) error {
+	if _, ok := that.mirrors()[name]; !ok {
+		return fmt.Errorf("cannot find maven mirror: %s, run 'gvc maven mirror list' to show mirrors", name)
+	}
+	that.Conf.Maven.Mirror = name
+	that.Conf.Restore()
+	if err := that.writeSettings(); err != nil {
+		return err
+	}
+	gprint.PrintSuccess(fmt.Sprintf("Use maven mirror %s.", name))
+	return nil
+}
+
+// RemoveMirror removes a mirror from the config, the mirror in use cannot be removed.
+func (that *MavenVersion) RemoveMirror(name string) error {
+	mirrors := that.mirrors()
+	if _, ok := mirrors[name]; !ok {
+		return fmt.Errorf("cannot find maven mirror: %s", name)
+	}
+	if name == that.Conf.Maven.Mirror {
+		return fmt.Errorf("maven mirror %s is in use", name)
+	}
+	delete(mirrors, name)
+	that.Conf.Restore()
+	gprint.PrintSuccess(fmt.Sprintf("Removed maven mirror %s.", name))
+	return nil
+}
@@ -0,0 +1,366 @@
+package vctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+	"github.com/moqsien/gvc/pkgs/utils/sorts"
+)
+
+/*
+Serve downloaded sdk archives to the LAN, with indexes in the same shape as the official sites:
+
+	/go/?mode=json           go.dev/dl json api
+	/nodejs/index.json       nodejs.org/dist/index.json
+	/julia/bin/versions.json julialang-s3.julialang.org/bin/versions.json
+	/files/<sdk>/            other downloaded files
+
+Signatures are not served, clients download them from SignatureUrl of go and nodejs.
+*/
+type mirrorFile struct {
+	SDK     string
+	Version string
+	OS      string
+	Arch    string
+	Ext     string
+	Lts     string
+	Path    string
+	Size    int64
+	ModTime time.Time
+	Sha256  string
+}
+
+// Name returns the file name used by the official site.
+func (that *mirrorFile) Name() string {
+	switch that.SDK {
+	case "go":
+		arch := that.Arch
+		if arch == "arm" {
+			arch = "armv6l"
+		}
+		return fmt.Sprintf("go%s.%s-%s%s", that.Version, that.OS, arch, that.Ext)
+	case "nodejs":
+		return fmt.Sprintf("node-%s-%s-%s%s", that.Version, PlatformList[that.OS], PlatformList[that.Arch], that.Ext)
+	default:
+		return fmt.Sprintf("%s-%s-%s-%s%s", that.SDK, that.Version, that.OS, that.Arch, that.Ext)
+	}
+}
+
+// names of downloaded files, see download() of each sdk.
+var (
+	goMirrorRegexp    = regexp.MustCompile(`^go-(.+)-(\w+)\.(\w+)(\.tar\.gz|\.zip)$`)
+	nodeMirrorRegexp  = regexp.MustCompile(`^nodejs(v[\d.]+)(\(([^)]*)\))?-(\w+)-(\w+)(\.tar\.gz|\.zip)$`)
+	juliaMirrorRegexp = regexp.MustCompile(`^julia-(.+)-(\w+)-(\w+)(\.tar\.gz|\.zip|\.tar\.xz)$`)
+)
+
+func parseMirrorFile(fileName string) *mirrorFile {
+	if sList := goMirrorRegexp.FindStringSubmatch(fileName); len(sList) == 5 {
+		return &mirrorFile{SDK: "go", Version: sList[1], OS: sList[2], Arch: sList[3], Ext: sList[4]}
+	}
+	if sList := nodeMirrorRegexp.FindStringSubmatch(fileName); len(sList) == 7 {
+		return &mirrorFile{SDK: "nodejs", Version: sList[1], Lts: sList[3], OS: sList[4], Arch: sList[5], Ext: sList[6]}
+	}
+	if sList := juliaMirrorRegexp.FindStringSubmatch(fileName); len(sList) == 5 {
+		return &mirrorFile{SDK: "julia", Version: sList[1], OS: sList[2], Arch: sList[3], Ext: sList[4]}
+	}
+	return nil
+}
+
+type MirrorServer struct {
+	Addr   string
+	files  map[string][]*mirrorFile
+	hashes map[string]string
+	lock   sync.Mutex
+}
+
+func NewMirrorServer(addr string) *MirrorServer {
+	return &MirrorServer{
+		Addr:   addr,
+		hashes: map[string]string{},
+	}
+}
+
+func (that *MirrorServer) hashFile(fPath string, info os.FileInfo) string {
+	key := fmt.Sprintf("%s|%d|%d", fPath, info.Size(), info.ModTime().UnixNano())
+	if sum, ok := that.hashes[key]; ok {
+		return sum
+	}
+	sum, err := utils.HashFile(fPath, "sha256")
+	if err != nil {
+		gprint.PrintWarning(fmt.Sprintf("%+v", err))
+		return ""
+	}
+	that.hashes[key] = sum
+	return sum
+}
+
+// scan finds archives in the downloads dirs and the shared cache.
+func (that *MirrorServer) scan() map[string][]*mirrorFile {
+	that.lock.Lock()
+	defer that.lock.Unlock()
+
+	files := map[string][]*mirrorFile{}
+	found := map[string]struct{}{}
+	add := func(fileName, fPath, sha string) {
+		f := parseMirrorFile(fileName)
+		if f == nil {
+			return
+		}
+		if _, ok := found[f.Name()]; ok {
+			return
+		}
+		info, err := os.Stat(fPath)
+		if err != nil || info.IsDir() {
+			return
+		}
+		f.Path, f.Size, f.ModTime = fPath, info.Size(), info.ModTime()
+		if f.Sha256 = sha; f.Sha256 == "" {
+			f.Sha256 = that.hashFile(fPath, info)
+		}
+		found[f.Name()] = struct{}{}
+		files[f.SDK] = append(files[f.SDK], f)
+	}
+
+	dc := NewDownloadCache()
+	for _, e := range dc.Entries {
+		add(e.FileName, dc.blobPath(e), e.Sha256)
+	}
+	for _, dir := range []string{config.GoTarFilesPath, config.NodejsTarFiles, config.JuliaTarFilePath} {
+		if dList, err := os.ReadDir(dir); err == nil {
+			for _, d := range dList {
+				add(d.Name(), filepath.Join(dir, d.Name()), "")
+			}
+		}
+	}
+	that.files = files
+	return files
+}
+
+// sortedVersions returns versions from the newest.
+func sortedVersions(fList []*mirrorFile) (vList []string, byVersion map[string][]*mirrorFile) {
+	byVersion = map[string][]*mirrorFile{}
+	for _, f := range fList {
+		if _, ok := byVersion[f.Version]; !ok {
+			vList = append(vList, f.Version)
+		}
+		byVersion[f.Version] = append(byVersion[f.Version], f)
+	}
+	vList = sorts.SortGoVersion(vList)
+	for i, j := 0, len(vList)-1; i < j; i, j = i+1, j-1 {
+		vList[i], vList[j] = vList[j], vList[i]
+	}
+	return
+}
+
+func (that *MirrorServer) findFile(sdk, name string) *mirrorFile {
+	that.lock.Lock()
+	defer that.lock.Unlock()
+	for _, f := range that.files[sdk] {
+		if f.Name() == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func writeJson(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+func (that *MirrorServer) serveFile(w http.ResponseWriter, r *http.Request, sdk, name string) {
+	f := that.findFile(sdk, name)
+	if f == nil {
+		that.scan()
+		if f = that.findFile(sdk, name); f == nil {
+			http.NotFound(w, r)
+			return
+		}
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	http.ServeFile(w, r, f.Path)
+}
+
+func (that *MirrorServer) handleGo(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/go/")
+	if name != "" {
+		that.serveFile(w, r, "go", name)
+		return
+	}
+	vList, byVersion := sortedVersions(that.scan()["go"])
+	result := []*goJsonVersion{}
+	for _, v := range vList {
+		gv := &goJsonVersion{Version: "go" + v, Stable: !isPrerelease(v)}
+		for _, f := range byVersion[v] {
+			arch := f.Arch
+			if arch == "arm" {
+				arch = "armv6l"
+			}
+			gv.Files = append(gv.Files, &goJsonFile{
+				FileName: f.Name(),
+				OS:       f.OS,
+				Arch:     arch,
+				Version:  gv.Version,
+				Sha256:   f.Sha256,
+				Size:     f.Size,
+				Kind:     "archive",
+			})
+		}
+		result = append(result, gv)
+	}
+	writeJson(w, result)
+}
+
+func (that *MirrorServer) handleNodejs(w http.ResponseWriter, r *http.Request) {
+	pList := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/nodejs/"), "/", 2)
+	if pList[0] == "index.json" {
+		vList, byVersion := sortedVersions(that.scan()["nodejs"])
+		result := []*nV{}
+		for _, v := range vList {
+			item := &nV{Version: v, Lts: false, Date: byVersion[v][0].ModTime.Format("2006-01-02")}
+			// lts names are kept in downloaded file names, see NodeVersion.getVersions.
+			switch lts := byVersion[v][0].Lts; lts {
+			case "":
+			case "yes":
+				item.Lts = true
+			default:
+				item.Lts = lts
+			}
+			result = append(result, item)
+		}
+		writeJson(w, result)
+		return
+	}
+	if len(pList) == 2 && pList[1] != "" {
+		if pList[1] == "SHASUMS256.txt" {
+			that.scan()
+			that.lock.Lock()
+			defer that.lock.Unlock()
+			for _, f := range that.files["nodejs"] {
+				if f.Version == pList[0] {
+					fmt.Fprintf(w, "%s  %s\n", f.Sha256, f.Name())
+				}
+			}
+			return
+		}
+		that.serveFile(w, r, "nodejs", pList[1])
+		return
+	}
+	// the nodejs manager finds archives from links in the version page.
+	_, byVersion := sortedVersions(that.scan()["nodejs"])
+	fList, ok := byVersion[pList[0]]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<html><body>\n")
+	for _, f := range fList {
+		fmt.Fprintf(w, "<a href=\"%s\">%s</a><br>\n", html.EscapeString(f.Name()), html.EscapeString(f.Name()))
+	}
+	fmt.Fprintf(w, "<a href=\"SHASUMS256.txt\">SHASUMS256.txt</a>\n</body></html>\n")
+}
+
+var juliaMirrorNames = map[string]string{
+	utils.MacOS:   "mac",
+	utils.Linux:   "linux",
+	utils.Windows: "winnt",
+	"amd64":       "x64",
+	"arm64":       "aarch64",
+	"386":         "i686",
+}
+
+func (that *MirrorServer) handleJulia(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/julia/bin/")
+	if name != "versions.json" {
+		that.serveFile(w, r, "julia", name)
+		return
+	}
+	vList, byVersion := sortedVersions(that.scan()["julia"])
+	result := map[string]interface{}{}
+	for _, v := range vList {
+		fList := []map[string]interface{}{}
+		for _, f := range byVersion[v] {
+			fList = append(fList, map[string]interface{}{
+				"url":       fmt.Sprintf("http://%s/julia/bin/%s", r.Host, f.Name()),
+				"kind":      "archive",
+				"os":        juliaMirrorNames[f.OS],
+				"arch":      juliaMirrorNames[f.Arch],
+				"sha256":    f.Sha256,
+				"size":      f.Size,
+				"version":   v,
+				"extension": strings.TrimPrefix(f.Ext, "."),
+			})
+		}
+		result[v] = map[string]interface{}{
+			"files":  fList,
+			"stable": !isPrerelease(v),
+		}
+	}
+	writeJson(w, result)
+}
+
+func (that *MirrorServer) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	base := fmt.Sprintf("http://%s", r.Host)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "gvc mirror\n\n")
+	fmt.Fprintf(w, "go.compiler_urls:        %s/go/\n", base)
+	fmt.Fprintf(w, "nodejs.compiler_url:     %s/nodejs/index.json\n", base)
+	fmt.Fprintf(w, "nodejs.release_url:      %s/nodejs/\n", base)
+	fmt.Fprintf(w, "julia.version_url:       %s/julia/bin/versions.json\n", base)
+	fmt.Fprintf(w, "julia.base_url:          %s/julia/bin/\n", base)
+	fmt.Fprintf(w, "other files:             %s/files/\n", base)
+}
+
+// other downloaded files, without indexes.
+var mirrorFileDirs = map[string]string{
+	"java":    config.JavaTarFilesPath,
+	"flutter": config.FlutterTarFilePath,
+	"vscode":  config.CodeTarFileDir,
+}
+
+func (that *MirrorServer) handleFiles(w http.ResponseWriter, r *http.Request) {
+	pList := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/files/"), "/", 2)
+	dir, ok := mirrorFileDirs[pList[0]]
+	if !ok {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		for name := range mirrorFileDirs {
+			fmt.Fprintf(w, "<a href=\"%s/\">%s/</a><br>\n", name, name)
+		}
+		return
+	}
+	http.StripPrefix("/files/"+pList[0], http.FileServer(http.Dir(dir))).ServeHTTP(w, r)
+}
+
+// Serve blocks and serves the mirror.
+func (that *MirrorServer) Serve() error {
+	files := that.scan()
+	for _, sdk := range []string{"go", "nodejs", "julia"} {
+		gprint.PrintInfo(fmt.Sprintf("%s: %d archives", sdk, len(files[sdk])))
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", that.handleIndex)
+	mux.HandleFunc("/go/", that.handleGo)
+	mux.HandleFunc("/nodejs/", that.handleNodejs)
+	mux.HandleFunc("/julia/bin/", that.handleJulia)
+	mux.HandleFunc("/files/", that.handleFiles)
+	gprint.PrintSuccess(fmt.Sprintf("Serving mirror on %s, open it in a browser for config hints.", that.Addr))
+	return http.ListenAndServe(that.Addr, mux)
+}
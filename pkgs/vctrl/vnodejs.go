@@ -130,8 +130,43 @@ func (that *NodeVersion) parseLTS(v any) (r string) {
 }
 
 func (that *NodeVersion) ShowVersions() {
-	fc := gprint.NewFadeColors(that.getVersions())
-	fc.Println()
+	vList := that.getVersions()
+	if !IsMachineOutput() {
+		fc := gprint.NewFadeColors(vList)
+		fc.Println()
+		return
+	}
+	current := that.getCurrent()
+	infos := []*VersionInfo{}
+	for _, v := range vList {
+		version := strings.Split(v, "(")[0]
+		info := &VersionInfo{Version: version, OS: runtime.GOOS, Arch: runtime.GOARCH, Current: version == current}
+		if p, ok := that.Versions[version]; ok {
+			info.Url, _ = url.JoinPath(p.VUrl, fmt.Sprintf("node-%s-%s-%s%s",
+				version, PlatformList[runtime.GOOS], PlatformList[runtime.GOARCH], that.getSuffix()))
+		}
+		info.Installed, _ = utils.PathIsExist(filepath.Join(config.NodejsUntarFiles, version))
+		infos = append(infos, info)
+	}
+	PrintVersionInfos(infos)
+}
+
+// getShasums returns SHASUMS256.txt of a release, after the signature of the release team is verified.
+// The signed file is downloaded from SignatureUrl, mirrors like gvc mirror serve do not provide it.
+func (that *NodeVersion) getShasums(version, vUrl string) (string, bool) {
+	if skipSignature() {
+		sUrl, _ := url.JoinPath(vUrl, "SHASUMS256.txt")
+		return getText(sUrl), true
+	}
+	sigBase := that.Conf.Nodejs.SignatureUrl
+	if sigBase == "" {
+		// configs of older versions have no signature url.
+		cnf := config.NewNodejsConf()
+		cnf.Reset()
+		sigBase = cnf.SignatureUrl
+	}
+	sUrl, _ := url.JoinPath(sigBase, version, "SHASUMS256.txt.asc")
+	return verifiedClearsigned(sUrl)
 }
 
 func (that *NodeVersion) download(version string) string {
@@ -158,29 +193,22 @@ func (that *NodeVersion) download(version string) string {
 		}
 
 		if v.Url != "" {
-			that.fetcher.Url, _ = url.JoinPath(v.VUrl, "SHASUMS256.txt")
-			if resp := that.fetcher.Get(); resp != nil {
-				content, _ := io.ReadAll(resp.RawBody())
-				sumList := strings.Split(string(content), "\n")
-				nameList := strings.Split(v.Url, "/")
-				for _, vl := range sumList {
-					if strings.Contains(vl, nameList[len(nameList)-1]) {
-						v.Checksum = strings.Trim(strings.Split(vl, " ")[0], " ")
-					}
-				}
+			shasums, ok := that.getShasums(version, v.VUrl)
+			if !ok {
+				return ""
 			}
+			nameList := strings.Split(v.Url, "/")
+			v.Checksum = findChecksumLine(shasums, nameList[len(nameList)-1])
 
-			if v.Checksum != "" {
-				that.fetcher.Url = v.Url
-				that.fetcher.Timeout = 100 * time.Minute
-				fpath := filepath.Join(config.NodejsTarFiles, v.FileName)
-				that.fetcher.SetThreadNum(8)
-				if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
-					if ok := utils.CheckFile(fpath, "sha256", v.Checksum); ok {
-						return fpath
-					} else {
-						os.RemoveAll(fpath)
-					}
+			that.fetcher.Url = v.Url
+			that.fetcher.Timeout = 100 * time.Minute
+			fpath := filepath.Join(config.NodejsTarFiles, v.FileName)
+			that.fetcher.SetThreadNum(8)
+			if size := cachedDownload(that.fetcher, fpath, "sha256", v.Checksum); size > 0 {
+				if ok := utils.VerifyFile(fpath, "sha256", v.Checksum); ok {
+					return fpath
+				} else {
+					os.RemoveAll(fpath)
 				}
 			}
 		}
@@ -217,11 +245,32 @@ func (that *NodeVersion) findDir(untarfile string) {
 	}
 }
 
-func (that *NodeVersion) UseVersion(version string) {
+// normalizeVersion turns versions like "20.11.0", "20" or "lts/iron" into "v20.11.0".
+func (that *NodeVersion) normalizeVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if NeedsResolving(version) {
+		return resolveOrInstalled(that, "nodejs", version, config.NodejsUntarFiles)
+	}
+	return "v" + strings.TrimPrefix(version, "v")
+}
+
+// InstallVersion downloads and unarchives a version without switching to it.
+// Returns the NODE_HOME of the installed version.
+func (that *NodeVersion) InstallVersion(version string) (nodeHome string) {
+	version = that.normalizeVersion(version)
+	return that.install(version, that.download)
+}
+
+// InstallArchive unarchives a local archive file as InstallVersion does after download.
+func (that *NodeVersion) InstallArchive(version, archive string) (nodeHome string) {
+	version = "v" + strings.TrimPrefix(version, "v")
+	return that.install(version, func(string) string { return archive })
+}
+
+func (that *NodeVersion) install(version string, getArchive func(version string) string) (nodeHome string) {
 	untarfile := filepath.Join(config.NodejsUntarFiles, version)
-	var tarfile string
 	if ok, _ := utils.PathIsExist(untarfile); !ok {
-		if tarfile = that.download(version); tarfile != "" {
+		if tarfile := getArchive(version); tarfile != "" {
 			if err := archiver.Unarchive(tarfile, untarfile); err != nil {
 				os.RemoveAll(untarfile)
 				gprint.PrintError(fmt.Sprintf("Unarchive failed: %+v", err))
@@ -229,23 +278,29 @@ func (that *NodeVersion) UseVersion(version string) {
 			}
 		}
 	}
+	that.dir = ""
+	that.findDir(untarfile)
+	return that.dir
+}
+
+func (that *NodeVersion) UseVersion(version string) bool {
+	version = that.normalizeVersion(version)
+	if that.InstallVersion(version) == "" {
+		return false
+	}
 	if ok, _ := utils.PathIsExist(config.NodejsRoot); ok {
 		os.RemoveAll(config.NodejsRoot)
 	}
-	that.findDir(untarfile)
-	if that.dir == "" {
-		return
-	}
 	if err := utils.MkSymLink(that.dir, config.NodejsRoot); err != nil {
 		gprint.PrintError(fmt.Sprintf("Create link failed: %+v", err))
-		return
+		return false
 	}
 	vFilePath := filepath.Join(that.dir, "version.txt")
 	if ok, _ := utils.PathIsExist(vFilePath); !ok {
 		vFile, err := os.OpenFile(vFilePath, os.O_WRONLY|os.O_CREATE, os.ModePerm)
 		if err != nil {
 			gprint.PrintError(fmt.Sprintf("Open file failed: %+v", err))
-			return
+			return false
 		}
 		defer vFile.Close()
 		io.Copy(vFile, bytes.NewBuffer([]byte(version)))
@@ -253,6 +308,8 @@ func (that *NodeVersion) UseVersion(version string) {
 	that.setEnv(config.NodejsRoot)
 	that.setNpm()
 	gprint.PrintSuccess(fmt.Sprintf("Use %s succeeded!", version))
+	syncToolsAfterSwitch(that.Conf, ToolsNodejs)
+	return true
 }
 
 func (that *NodeVersion) getCurrent() (v string) {
@@ -267,17 +324,21 @@ func (that *NodeVersion) getCurrent() (v string) {
 
 func (that *NodeVersion) ShowInstalled() {
 	current := that.getCurrent()
+	infos := []*VersionInfo{}
 	if rd, err := os.ReadDir(config.NodejsUntarFiles); err == nil {
 		for _, v := range rd {
 			if v.IsDir() {
-				if current == v.Name() {
-					gprint.Yellow("%s <Current>", v.Name())
-					continue
-				}
-				gprint.Cyan(v.Name())
+				infos = append(infos, &VersionInfo{
+					Version:   v.Name(),
+					OS:        runtime.GOOS,
+					Arch:      runtime.GOARCH,
+					Installed: true,
+					Current:   current == v.Name(),
+				})
 			}
 		}
 	}
+	printInstalled(infos)
 }
 
 func (that *NodeVersion) RemoveVersion(version string) {
@@ -312,6 +373,10 @@ func (that *NodeVersion) RemoveVersion(version string) {
 	}
 }
 
+func (that *NodeVersion) RemoveUnused() {
+	that.RemoveVersion("all")
+}
+
 func (that *NodeVersion) setNpm() {
 	var binPath string
 	if runtime.GOOS == utils.Windows {
@@ -48,7 +48,11 @@ func (that *NVim) download() (r string) {
 		that.fetcher.SetThreadNum(3)
 		fpath := filepath.Join(config.NVimFileDir, fmt.Sprintf("%s%s", nurl.Name, nurl.Ext))
 		if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
-			r = fpath
+			if verifyGithubAsset(fpath, nurl.Url) {
+				r = fpath
+			} else {
+				os.RemoveAll(fpath)
+			}
 		}
 	} else {
 		gprint.PrintError(fmt.Sprintf("Cannot find nvim package for %s", runtime.GOOS))
@@ -0,0 +1,89 @@
+package vctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+)
+
+/*
+Machine-readable output for version listings.
+*/
+const (
+	OutputText  string = "text"
+	OutputJSON  string = "json"
+	OutputPlain string = "plain"
+)
+
+// OutputFormat is set by --json or --plain.
+var OutputFormat = OutputText
+
+// VersionInfo is a version in listings, url and checksum are omitted when the source has no such info:
+// local listings of installed versions have neither, nodejs index.json has no checksums,
+// they are in SHASUMS256.txt of each release, which is only downloaded when installing.
+type VersionInfo struct {
+	Version   string `json:"version"`
+	Vendor    string `json:"vendor,omitempty"`
+	OS        string `json:"os,omitempty"`
+	Arch      string `json:"arch,omitempty"`
+	Url       string `json:"url,omitempty"`
+	Checksum  string `json:"checksum,omitempty"`
+	Installed bool   `json:"installed"`
+	Current   bool   `json:"current"`
+}
+
+func IsMachineOutput() bool {
+	return OutputFormat == OutputJSON || OutputFormat == OutputPlain
+}
+
+// PrintVersionInfos prints infos as json, or one tab-separated line per version:
+// version, os/arch, installed, current, url, checksum, omitted url and checksum are empty columns.
+func PrintVersionInfos(infos []*VersionInfo) {
+	if infos == nil {
+		infos = []*VersionInfo{}
+	}
+	if OutputFormat == OutputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(infos); err != nil {
+			gprint.PrintError("%+v", err)
+		}
+		return
+	}
+	for _, info := range infos {
+		osArch := ""
+		if info.OS != "" || info.Arch != "" {
+			osArch = fmt.Sprintf("%s/%s", info.OS, info.Arch)
+		}
+		fmt.Println(strings.Join([]string{
+			info.Version,
+			osArch,
+			fmt.Sprintf("%t", info.Installed),
+			fmt.Sprintf("%t", info.Current),
+			info.Url,
+			info.Checksum,
+		}, "\t"))
+	}
+}
+
+// printInstalled prints installed versions, the current one is highlighted in text mode.
+func printInstalled(infos []*VersionInfo) {
+	if IsMachineOutput() {
+		PrintVersionInfos(infos)
+		return
+	}
+	for _, info := range infos {
+		name := info.Version
+		if info.Vendor != "" {
+			name = fmt.Sprintf("%s (%s)", info.Version, info.Vendor)
+		}
+		if info.Current {
+			gprint.Yellow("%s <Current>", name)
+		} else {
+			gprint.Cyan(name)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package vctrl
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	config "github.com/moqsien/gvc/pkgs/confs"
+)
+
+/*
+Project-local version pinning.
+
+A .gvc-versions file looks like:
+
+	# comments are allowed
+	go 1.21.5
+	nodejs 20.11.0
+	java 17
+	nodejs lts/iron
+
+Constraints and aliases are resolved to exact versions stored in .gvc-versions.lock.
+*/
+type PinnedVersion struct {
+	Name    string
+	Version string
+	File    string
+}
+
+func isFile(fPath string) bool {
+	info, err := os.Stat(fPath)
+	return err == nil && !info.IsDir()
+}
+
+// FindPinFile searches for a .gvc-versions file from dir up to the root.
+func FindPinFile(dir string) string {
+	dir, _ = filepath.Abs(dir)
+	for {
+		fPath := filepath.Join(dir, config.GVCVersionsFileName)
+		if isFile(fPath) {
+			return fPath
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ParsePinFile parses a .gvc-versions file.
+func ParsePinFile(fPath string) (r []*PinnedVersion, err error) {
+	f, err := os.Open(fPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%s:%d: expected <sdk> <version>, got %q", fPath, lineNum, strings.TrimSpace(line))
+		}
+		s := findSDK(fields[0])
+		if s == nil {
+			return nil, fmt.Errorf("%s:%d: unsupported sdk: %s", fPath, lineNum, fields[0])
+		}
+		// constraints like ">=1.21 <1.22" contain spaces.
+		r = append(r, &PinnedVersion{Name: s.Name, Version: strings.Join(fields[1:], " "), File: fPath})
+	}
+	err = scanner.Err()
+	return
+}
+
+// GetPinnedVersions returns versions pinned for dir, with constraints replaced by locked versions.
+func GetPinnedVersions(dir string) (r []*PinnedVersion, err error) {
+	if fPath := FindPinFile(dir); fPath != "" {
+		if r, err = ParsePinFile(fPath); err == nil {
+			applyLockFile(r)
+		}
+	}
+	return
+}
+
+// UsePinnedVersions installs and switches to versions pinned for the present working directory.
+// With detect, version files of other tools are also used.
+// ok is false when no versions are found, or any of them failed.
+func UsePinnedVersions(detect bool) (ok bool) {
+	cwd, _ := os.Getwd()
+	var (
+		pinned []*PinnedVersion
+		err    error
+	)
+	if detect {
+		pinned, err = DetectVersions(cwd)
+	} else {
+		pinned, err = GetPinnedVersions(cwd)
+	}
+	if err != nil {
+		gprint.PrintError("%+v", err)
+		return false
+	}
+	if len(pinned) == 0 {
+		if detect {
+			gprint.PrintWarning(fmt.Sprintf("Cannot find any version files in %s or its parent dirs.", cwd))
+		} else {
+			gprint.PrintWarning(fmt.Sprintf("Cannot find %s in %s or its parent dirs.", config.GVCVersionsFileName, cwd))
+		}
+		return false
+	}
+	ok = true
+	for _, p := range pinned {
+		m, err := GetSDKManager(p.Name)
+		if err != nil {
+			gprint.PrintError("%+v", err)
+			ok = false
+			continue
+		}
+		version := lockPinnedVersion(m, p)
+		gprint.PrintInfo(fmt.Sprintf("Using %s %s from %s.", p.Name, version, p.File))
+		if !m.UseVersion(version) {
+			ok = false
+		}
+	}
+	return
+}
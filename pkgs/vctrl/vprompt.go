@@ -0,0 +1,97 @@
+package vctrl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/confirm"
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/goutils/pkgs/gtea/selector"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+/*
+Prompts that can be answered by flags in non-interactive mode.
+*/
+func exitForPrompt(flagName string) {
+	if flagName != "" {
+		gprint.PrintError(fmt.Sprintf("Cannot prompt in non-interactive mode, please use --%s or --yes.", flagName))
+	} else {
+		gprint.PrintError("Cannot prompt in non-interactive mode, please use --yes.")
+	}
+	os.Exit(1)
+}
+
+// rememberAnswer saves an answer given by flags to the gvc config,
+// and returns the saved one.
+func rememberAnswer(conf *config.GVConfig, saved *string, answer string) string {
+	if answer != "" && answer != *saved {
+		*saved = answer
+		conf.Restore()
+	}
+	return *saved
+}
+
+// selectOne returns the value of the item matching the answer.
+// Without an answer, a selector is shown. In non-interactive mode,
+// the first item is chosen with --yes, otherwise gvc exits.
+// With json or plain output, the first item is the default, selectors never write to stdout.
+func selectOne(itemList *selector.ItemList, answer, flagName string, opts ...selector.SOption) interface{} {
+	if answer != "" {
+		keys := []string{}
+		for _, item := range itemList.Keys() {
+			key := string(item)
+			keys = append(keys, key)
+			if strings.Contains(strings.ToLower(key), strings.ToLower(answer)) {
+				return itemList.Get(key)
+			}
+			if val, ok := itemList.Get(key).(string); ok && val == answer {
+				return val
+			}
+		}
+		gprint.PrintError(fmt.Sprintf("Unknown value for --%s: %s. Available: %s", flagName, answer, strings.Join(keys, " | ")))
+		os.Exit(1)
+	}
+	if keys := itemList.Keys(); IsMachineOutput() && len(keys) > 0 {
+		return itemList.Get(string(keys[0]))
+	}
+	if !utils.CanPrompt() {
+		if keys := itemList.Keys(); utils.Answers.AssumeYes && len(keys) > 0 {
+			return itemList.Get(string(keys[0]))
+		}
+		exitForPrompt(flagName)
+	}
+	opts = append(opts, selector.WidthEnableMulti(false))
+	sel := selector.NewSelector(itemList, opts...)
+	sel.Run()
+	vList := sel.Value()
+	if len(vList) == 0 {
+		gprint.PrintError("Nothing is chosen.")
+		os.Exit(1)
+	}
+	return vList[0]
+}
+
+// confirmOrNot returns the answer if it is given.
+// Otherwise a confirm is shown. In non-interactive mode,
+// true is returned with --yes, otherwise gvc exits.
+func confirmOrNot(title, answer, flagName string) bool {
+	if answer != "" {
+		if r, ok := utils.ParseYesNo(answer); ok {
+			return r
+		}
+		gprint.PrintError(fmt.Sprintf("Unknown value for --%s: %s. Available: yes | no", flagName, answer))
+		os.Exit(1)
+	}
+	if !utils.CanPrompt() {
+		if utils.Answers.AssumeYes {
+			return true
+		}
+		exitForPrompt(flagName)
+	}
+	cfm := confirm.NewConfirm(confirm.WithTitle(title))
+	cfm.Run()
+	return cfm.Result()
+}
@@ -36,6 +36,11 @@ func (that *VProtoBuffer) Install(force bool) {
 	}
 	that.fetcher.Url = that.Conf.Protobuf.GithubUrls[key]
 	if that.fetcher.Url != "" {
+		cType, cSum, ok := requireGithubDigest(that.fetcher.Url)
+		if !ok {
+			return
+		}
+		that.fetcher.SetCheckSum(cSum, cType)
 		that.fetcher.Url = that.Conf.GVCProxy.WrapUrl(that.fetcher.Url)
 		that.fetcher.Timeout = 20 * time.Minute
 		that.fetcher.SetThreadNum(2)
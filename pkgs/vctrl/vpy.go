@@ -12,7 +12,6 @@ import (
 
 	"github.com/mholt/archiver/v3"
 	myArchiver "github.com/moqsien/goutils/pkgs/archiver"
-	"github.com/moqsien/goutils/pkgs/gtea/confirm"
 	"github.com/moqsien/goutils/pkgs/gtea/gprint"
 	"github.com/moqsien/goutils/pkgs/request"
 	config "github.com/moqsien/gvc/pkgs/confs"
@@ -106,10 +105,8 @@ func (that *PyVenv) setEnv() {
 }
 
 func (that *PyVenv) modifyAccelertion(pyenvDir string) {
-	cfm := confirm.NewConfirm(confirm.WithTitle("Set download accelerations in China or not?"))
-	cfm.Run()
-	result := cfm.Result()
-	if !result {
+	mirror := rememberAnswer(that.Conf, &that.Conf.Python.UseMirror, utils.Answers.Mirror)
+	if !confirmOrNot("Set download accelerations in China or not?", mirror, "mirror") {
 		return
 	}
 	if runtime.GOOS == utils.Windows {
@@ -273,11 +270,31 @@ func (that *PyVenv) ListRemoteVersions() {
 			}
 			newList = append(newList, v)
 		}
+		if IsMachineOutput() {
+			that.printVersionInfos(newList)
+			return
+		}
 		fc := gprint.NewFadeColors(newList)
 		fc.Println()
 	}
 }
 
+func (that *PyVenv) printVersionInfos(vList []string) {
+	versionsDir := filepath.Join(config.GetPyenvRootPath(), "versions")
+	content, _ := os.ReadFile(filepath.Join(config.GetPyenvRootPath(), "version"))
+	current := strings.TrimSpace(string(content))
+	infos := []*VersionInfo{}
+	for _, v := range vList {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		installed, _ := utils.PathIsExist(filepath.Join(versionsDir, v))
+		infos = append(infos, &VersionInfo{Version: v, Installed: installed, Current: v == current})
+	}
+	PrintVersionInfos(infos)
+}
+
 func (that *PyVenv) isInstalled(version string) (r bool) {
 	cmd := exec.Command(that.getExecutablePath(), "versions")
 	cmd.Env = os.Environ()
@@ -338,7 +355,7 @@ func (that *PyVenv) getInstallNeededForWin(version string) {
 	}
 }
 
-func (that *PyVenv) InstallVersion(version string, useDefault bool) {
+func (that *PyVenv) install(version string, useDefault bool) {
 	that.getPyenv()
 	that.setTempEnvs()
 	if !that.isInstalled(version) {
@@ -353,6 +370,10 @@ func (that *PyVenv) InstallVersion(version string, useDefault bool) {
 		}
 		utils.ExecuteSysCommand(false, that.getExecutablePath(), "install", version)
 	}
+}
+
+func (that *PyVenv) InstallVersion(version string, useDefault bool) {
+	that.install(version, useDefault)
 	utils.ExecuteSysCommand(false, that.getExecutablePath(), "global", version)
 	that.setPipAcceleration()
 }
@@ -364,6 +385,17 @@ func (that *PyVenv) RemoveVersion(version string) {
 }
 
 func (that *PyVenv) ShowInstalled() {
+	if IsMachineOutput() {
+		vList := []string{}
+		dList, _ := os.ReadDir(filepath.Join(config.GetPyenvRootPath(), "versions"))
+		for _, d := range dList {
+			if d.IsDir() {
+				vList = append(vList, d.Name())
+			}
+		}
+		that.printVersionInfos(vList)
+		return
+	}
 	that.getPyenv()
 	that.setTempEnvs()
 	utils.ExecuteSysCommand(false, that.getExecutablePath(), "versions")
@@ -407,3 +439,32 @@ func (that *PyVenv) FixSystemGenerationsForWin() {
 		}
 	}
 }
+
+/*
+SDKManager for python, based on pyenv.
+*/
+type PySDK struct {
+	*PyVenv
+}
+
+func NewPySDK() *PySDK {
+	return &PySDK{PyVenv: NewPyVenv()}
+}
+
+func (that *PySDK) ShowVersions() {
+	that.ListRemoteVersions()
+}
+
+func (that *PySDK) InstallVersion(version string) string {
+	that.install(version, false)
+	return findVersionDir(filepath.Join(config.GetPyenvRootPath(), "versions"), version)
+}
+
+func (that *PySDK) UseVersion(version string) bool {
+	that.PyVenv.InstallVersion(version, false)
+	return findVersionDir(filepath.Join(config.GetPyenvRootPath(), "versions"), version) != ""
+}
+
+func (that *PySDK) RemoveUnused() {
+	gprint.PrintWarning("Removing unused versions is not supported for python, please remove versions one by one.")
+}
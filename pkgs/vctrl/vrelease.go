@@ -0,0 +1,272 @@
+package vctrl
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+/*
+Release artifacts of gvc go build: archives with extra files, SHA256SUMS and manifest.json.
+*/
+const (
+	ArchiveZip           string = "zip"
+	ArchiveTarGz         string = "tar.gz"
+	releaseChecksumsFile string = "SHA256SUMS"
+	releaseManifestFile  string = "manifest.json"
+)
+
+type ReleaseArtifact struct {
+	Target   string `json:"target"`
+	FileName string `json:"filename"`
+	Size     int64  `json:"size"`
+	Sha256   string `json:"sha256"`
+}
+
+type ReleaseManifest struct {
+	GoVersion string             `json:"go_version"`
+	GitCommit string             `json:"git_commit"`
+	CreatedAt time.Time          `json:"created_at"`
+	Artifacts []*ReleaseArtifact `json:"artifacts"`
+}
+
+type archiveEntry struct {
+	Name string
+	Src  string
+}
+
+func (that *GoBuildArchOS) archiveFormat(goos string) string {
+	if f := that.ArchiveFormats[goos]; f != "" {
+		return f
+	}
+	if that.ArchiveFormat != "" {
+		return that.ArchiveFormat
+	}
+	return ArchiveZip
+}
+
+// collectExtraFiles expands glob patterns of extra files, dirs are added recursively.
+func collectExtraFiles(patterns []string) (entries []*archiveEntry, err error) {
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("cannot find extra file: %s", pattern)
+		}
+		for _, m := range matches {
+			base := filepath.Dir(m)
+			err = filepath.WalkDir(m, func(p string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				rel, err := filepath.Rel(base, p)
+				if err != nil {
+					return err
+				}
+				entries = append(entries, &archiveEntry{Name: filepath.ToSlash(rel), Src: p})
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return
+}
+
+func zipFiles(dst string, entries []*archiveEntry) (err error) {
+	fw, err := os.Create(dst)
+	if err != nil {
+		return
+	}
+	zw := zip.NewWriter(fw)
+	err = writeZipEntries(zw, entries)
+	return closeWriters(err, zw, fw)
+}
+
+func writeZipEntries(zw *zip.Writer, entries []*archiveEntry) error {
+	for _, e := range entries {
+		info, err := os.Stat(e.Src)
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = e.Name
+		header.Method = zip.Deflate
+		writer, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if err = copyFileTo(writer, e.Src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tarGzFiles(dst string, entries []*archiveEntry) (err error) {
+	fw, err := os.Create(dst)
+	if err != nil {
+		return
+	}
+	gw := gzip.NewWriter(fw)
+	tw := tar.NewWriter(gw)
+	err = writeTarEntries(tw, entries)
+	return closeWriters(err, tw, gw, fw)
+}
+
+func writeTarEntries(tw *tar.Writer, entries []*archiveEntry) error {
+	for _, e := range entries {
+		info, err := os.Stat(e.Src)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = e.Name
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if err = copyFileTo(tw, e.Src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeWriters closes writers in order, from the outermost one to the file,
+// and returns the first error, an archive is incomplete if any of them fails.
+func closeWriters(err error, writers ...io.Closer) error {
+	for _, w := range writers {
+		if cErr := w.Close(); err == nil {
+			err = cErr
+		}
+	}
+	return err
+}
+
+func copyFileTo(w io.Writer, src string) error {
+	fr, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer fr.Close()
+	_, err = io.Copy(w, fr)
+	return err
+}
+
+// archive packs a compiled binary and extra files into <name>_<os>-<arch>.<format>.
+func (that *GoVersion) archive(binPath, buildBaseDir, goos, dirName string, bConf *GoBuildArchOS) (dst string, err error) {
+	if binPath == "" {
+		return "", fmt.Errorf("cannot find compiled binary for %s", dirName)
+	}
+	binName := filepath.Base(binPath)
+	entries := []*archiveEntry{{Name: binName, Src: binPath}}
+	extra, err := collectExtraFiles(bConf.ExtraFiles)
+	if err != nil {
+		return
+	}
+	entries = append(entries, extra...)
+
+	format := bConf.archiveFormat(goos)
+	name := strings.TrimSuffix(binName, filepath.Ext(binName))
+	dst = filepath.Join(buildBaseDir, fmt.Sprintf("%s_%s.%s", name, dirName, format))
+	if ok, _ := utils.PathIsExist(dst); ok {
+		os.RemoveAll(dst)
+	}
+	switch format {
+	case ArchiveZip:
+		err = zipFiles(dst, entries)
+	case ArchiveTarGz:
+		err = tarGzFiles(dst, entries)
+	default:
+		err = fmt.Errorf("unsupported archive format: %s, use %s or %s", format, ArchiveZip, ArchiveTarGz)
+	}
+	return
+}
+
+func commandOutput(args ...string) string {
+	out, err := exec.Command(args[0], args[1:]...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// writeReleaseFiles writes SHA256SUMS and manifest.json for artifacts of successful targets.
+func (that *GoVersion) writeReleaseFiles(buildBaseDir string, results []*goBuildResult, bConf *GoBuildArchOS) error {
+	if !bConf.Checksums && !bConf.Manifest {
+		return nil
+	}
+	m := &ReleaseManifest{
+		GoVersion: commandOutput("go", "env", "GOVERSION"),
+		GitCommit: commandOutput("git", "rev-parse", "HEAD"),
+		CreatedAt: time.Now(),
+		Artifacts: []*ReleaseArtifact{},
+	}
+	sums := []string{}
+	for _, r := range results {
+		if !r.Ok || r.Artifact == "" {
+			continue
+		}
+		info, err := os.Stat(r.Artifact)
+		if err != nil {
+			return err
+		}
+		sum, err := utils.HashFile(r.Artifact, "sha256")
+		if err != nil {
+			return err
+		}
+		fileName, err := filepath.Rel(buildBaseDir, r.Artifact)
+		if err != nil {
+			return err
+		}
+		fileName = filepath.ToSlash(fileName)
+		m.Artifacts = append(m.Artifacts, &ReleaseArtifact{
+			Target:   r.ArchOS,
+			FileName: fileName,
+			Size:     info.Size(),
+			Sha256:   sum,
+		})
+		sums = append(sums, fmt.Sprintf("%s  %s\n", sum, fileName))
+	}
+
+	if bConf.Checksums {
+		fpath := filepath.Join(buildBaseDir, releaseChecksumsFile)
+		if err := os.WriteFile(fpath, []byte(strings.Join(sums, "")), 0644); err != nil {
+			return err
+		}
+		gprint.PrintSuccess(fmt.Sprintf("Checksums are written to %s.", fpath))
+	}
+	if bConf.Manifest {
+		content, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return err
+		}
+		fpath := filepath.Join(buildBaseDir, releaseManifestFile)
+		if err = os.WriteFile(fpath, content, 0644); err != nil {
+			return err
+		}
+		gprint.PrintSuccess(fmt.Sprintf("Manifest is written to %s.", fpath))
+	}
+	return nil
+}
@@ -0,0 +1,473 @@
+package vctrl
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"golang.org/x/mod/modfile"
+)
+
+/*
+Rename a local go module.
+
+Only import specs of go files, module paths in go.mod, go.work and vendor/modules.txt are rewritten,
+string literals and comments are kept, and modules sharing a prefix with the old path are not touched.
+*/
+type moduleRenamer struct {
+	moduleDir string
+	oldName   string
+	newName   string
+	nested    []string          // paths of nested modules, which are not renamed.
+	changes   map[string]string // file path -> new content.
+	olds      map[string]string // file path -> old content.
+}
+
+// readModulePath returns the module path in a go.mod file.
+func readModulePath(modPath string) string {
+	content, err := os.ReadFile(modPath)
+	if err != nil {
+		return ""
+	}
+	return modfile.ModulePath(content)
+}
+
+// rename returns the new path for import paths in the renamed module.
+func (that *moduleRenamer) rename(p string) (string, bool) {
+	if p != that.oldName && !strings.HasPrefix(p, that.oldName+"/") {
+		return p, false
+	}
+	for _, n := range that.nested {
+		if p == n || strings.HasPrefix(p, n+"/") {
+			return p, false
+		}
+	}
+	return that.newName + strings.TrimPrefix(p, that.oldName), true
+}
+
+// renameToken renames a module path token of go.mod, which may be quoted.
+func (that *moduleRenamer) renameToken(tok string) (string, bool) {
+	p, err := strconv.Unquote(tok)
+	if err != nil {
+		p = tok
+	}
+	newPath, ok := that.rename(p)
+	if !ok {
+		return tok, false
+	}
+	return modfile.AutoQuote(newPath), true
+}
+
+// renameLineTokens renames tokens at idxList of a go.mod line, the directive is skipped for lines out of blocks.
+func (that *moduleRenamer) renameLineTokens(line *modfile.Line, idxList ...int) (changed bool) {
+	if line == nil {
+		return
+	}
+	offset := 1
+	if line.InBlock {
+		offset = 0
+	}
+	for _, idx := range idxList {
+		if idx+offset >= len(line.Token) {
+			continue
+		}
+		if tok, ok := that.renameToken(line.Token[idx+offset]); ok {
+			line.Token[idx+offset] = tok
+			changed = true
+		}
+	}
+	return
+}
+
+// renameReplace renames the old path of a replace directive, and the new path if it is not a local dir.
+func (that *moduleRenamer) renameReplace(r *modfile.Replace) bool {
+	idxList := []int{0}
+	if !modfile.IsDirectoryPath(r.New.Path) {
+		// old [version] => new [version]
+		idxList = append(idxList, 2)
+		if r.Old.Version != "" {
+			idxList[1]++
+		}
+	}
+	return that.renameLineTokens(r.Syntax, idxList...)
+}
+
+// rewriteModFile rewrites module paths in go.mod, the module directive is only renamed in the root go.mod.
+func (that *moduleRenamer) rewriteModFile(fpath, content string, isRootMod bool) (string, error) {
+	f, err := modfile.Parse(fpath, []byte(content), nil)
+	if err != nil {
+		return content, err
+	}
+	changed := false
+	if isRootMod && f.Module != nil {
+		changed = that.renameLineTokens(f.Module.Syntax, 0)
+	}
+	for _, r := range f.Require {
+		changed = that.renameLineTokens(r.Syntax, 0) || changed
+	}
+	for _, e := range f.Exclude {
+		changed = that.renameLineTokens(e.Syntax, 0) || changed
+	}
+	for _, r := range f.Replace {
+		changed = that.renameReplace(r) || changed
+	}
+	if !changed {
+		return content, nil
+	}
+	return string(modfile.Format(f.Syntax)), nil
+}
+
+// rewriteWorkFile rewrites replace directives of go.work, use directives are local dirs.
+func (that *moduleRenamer) rewriteWorkFile(fpath, content string) (string, error) {
+	f, err := modfile.ParseWork(fpath, []byte(content), nil)
+	if err != nil {
+		return content, err
+	}
+	changed := false
+	for _, r := range f.Replace {
+		changed = that.renameReplace(r) || changed
+	}
+	if !changed {
+		return content, nil
+	}
+	return string(modfile.Format(f.Syntax)), nil
+}
+
+// rewriteGoFile rewrites import specs only, positions are taken from go/parser.
+func (that *moduleRenamer) rewriteGoFile(fpath, content string) (string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, fpath, content, parser.ImportsOnly)
+	if err != nil {
+		return content, err
+	}
+	for i := len(f.Imports) - 1; i >= 0; i-- {
+		lit := f.Imports[i].Path
+		p, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			continue
+		}
+		if newPath, ok := that.rename(p); ok {
+			start, end := fset.Position(lit.Pos()).Offset, fset.Position(lit.End()).Offset
+			content = content[:start] + strconv.Quote(newPath) + content[end:]
+		}
+	}
+	return content, nil
+}
+
+// rewriteVendorModules rewrites vendor/modules.txt, which contains "# module version [=> replacement]" and package lines.
+func (that *moduleRenamer) rewriteVendorModules(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "## "):
+		case strings.HasPrefix(line, "# "):
+			fields := strings.Fields(strings.TrimPrefix(line, "# "))
+			for j, f := range fields {
+				if j == 0 || j > 0 && fields[j-1] == "=>" && !modfile.IsDirectoryPath(f) {
+					fields[j], _ = that.rename(f)
+				}
+			}
+			lines[i] = "# " + strings.Join(fields, " ")
+		case strings.TrimSpace(line) != "":
+			lines[i], _ = that.rename(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (that *moduleRenamer) addChange(fpath string, rewrite func(string) (string, error)) {
+	content, err := os.ReadFile(fpath)
+	if err != nil {
+		gprint.PrintWarning(fmt.Sprintf("Read %s failed: %+v", fpath, err))
+		return
+	}
+	newContent, err := rewrite(string(content))
+	if err != nil {
+		gprint.PrintWarning(fmt.Sprintf("Skipped %s: %+v", fpath, err))
+		return
+	}
+	if newContent != string(content) {
+		that.olds[fpath] = string(content)
+		that.changes[fpath] = newContent
+	}
+}
+
+func (that *moduleRenamer) collect() error {
+	goFiles, modFiles := []string{}, []string{}
+	err := filepath.WalkDir(that.moduleDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != that.moduleDir && (strings.HasPrefix(name, ".") || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch {
+		case strings.HasSuffix(name, ".go"):
+			goFiles = append(goFiles, p)
+		case name == "go.mod" || name == "go.work":
+			modFiles = append(modFiles, p)
+			if name == "go.mod" && filepath.Dir(p) != that.moduleDir {
+				if mPath := readModulePath(p); mPath != "" {
+					that.nested = append(that.nested, mPath)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range goFiles {
+		fpath := p
+		that.addChange(fpath, func(content string) (string, error) {
+			return that.rewriteGoFile(fpath, content)
+		})
+	}
+	rootMod := filepath.Join(that.moduleDir, "go.mod")
+	for _, p := range modFiles {
+		fpath, isRootMod := p, p == rootMod
+		that.addChange(fpath, func(content string) (string, error) {
+			if filepath.Base(fpath) == "go.work" {
+				return that.rewriteWorkFile(fpath, content)
+			}
+			return that.rewriteModFile(fpath, content, isRootMod)
+		})
+	}
+	if vendorModules := filepath.Join(that.moduleDir, "vendor", "modules.txt"); isFile(vendorModules) {
+		that.addChange(vendorModules, func(content string) (string, error) {
+			return that.rewriteVendorModules(content), nil
+		})
+	}
+	return nil
+}
+
+func (that *moduleRenamer) sortedChanges() (fList []string) {
+	for p := range that.changes {
+		fList = append(fList, p)
+	}
+	sort.Strings(fList)
+	return
+}
+
+func (that *moduleRenamer) write() error {
+	for _, p := range that.sortedChanges() {
+		info, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(p, []byte(that.changes[p]), info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	// vendored packages of the module are moved along.
+	oldVendor := filepath.Join(that.moduleDir, "vendor", filepath.FromSlash(that.oldName))
+	if ok, _ := isDir(oldVendor); ok {
+		newVendor := filepath.Join(that.moduleDir, "vendor", filepath.FromSlash(that.newName))
+		os.MkdirAll(filepath.Dir(newVendor), os.ModePerm)
+		return os.Rename(oldVendor, newVendor)
+	}
+	return nil
+}
+
+func isDir(p string) (bool, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffDelete
+	diffInsert
+)
+
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+// diffLines finds the longest common subsequence of lines, common prefix and suffix are trimmed first.
+func diffLines(aList, bList []string) (dList []*diffLine) {
+	prefix := 0
+	for prefix < len(aList) && prefix < len(bList) && aList[prefix] == bList[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(aList)-prefix && suffix < len(bList)-prefix && aList[len(aList)-1-suffix] == bList[len(bList)-1-suffix] {
+		suffix++
+	}
+	for _, l := range aList[:prefix] {
+		dList = append(dList, &diffLine{op: diffEqual, text: l})
+	}
+
+	a, b := aList[prefix:len(aList)-suffix], bList[prefix:len(bList)-suffix]
+	// lcs[i][j] is the length of the lcs of a[i:] and b[j:].
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			dList = append(dList, &diffLine{op: diffEqual, text: a[i]})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			dList = append(dList, &diffLine{op: diffInsert, text: b[j]})
+			j++
+		default:
+			dList = append(dList, &diffLine{op: diffDelete, text: a[i]})
+			i++
+		}
+	}
+
+	for _, l := range aList[len(aList)-suffix:] {
+		dList = append(dList, &diffLine{op: diffEqual, text: l})
+	}
+	return
+}
+
+// unifiedDiff returns the diff of a file in the unified format, with 3 lines of context.
+func unifiedDiff(name, a, b string) string {
+	const context = 3
+	dList := diffLines(splitLines(a), splitLines(b))
+	// numbers of lines of a and b before each diff line.
+	aBefore, bBefore := make([]int, len(dList)+1), make([]int, len(dList)+1)
+	for i, d := range dList {
+		aBefore[i+1], bBefore[i+1] = aBefore[i], bBefore[i]
+		if d.op != diffInsert {
+			aBefore[i+1]++
+		}
+		if d.op != diffDelete {
+			bBefore[i+1]++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- a/%s\n+++ b/%s\n", name, name))
+	for i := 0; i < len(dList); {
+		for i < len(dList) && dList[i].op == diffEqual {
+			i++
+		}
+		if i >= len(dList) {
+			break
+		}
+		start, end := i-context, i
+		if start < 0 {
+			start = 0
+		}
+		for end < len(dList) {
+			if dList[end].op != diffEqual {
+				end++
+				continue
+			}
+			j := end
+			for j < len(dList) && dList[j].op == diffEqual {
+				j++
+			}
+			if j == len(dList) || j-end > 2*context {
+				if end += context; end > len(dList) {
+					end = len(dList)
+				}
+				break
+			}
+			end = j
+		}
+		aStart, aCount := aBefore[start]+1, aBefore[end]-aBefore[start]
+		bStart, bCount := bBefore[start]+1, bBefore[end]-bBefore[start]
+		if aCount == 0 {
+			aStart--
+		}
+		if bCount == 0 {
+			bStart--
+		}
+		sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount))
+		for _, d := range dList[start:end] {
+			prefix := " "
+			switch d.op {
+			case diffDelete:
+				prefix = "-"
+			case diffInsert:
+				prefix = "+"
+			}
+			sb.WriteString(prefix + d.text)
+			if !strings.HasSuffix(d.text, "\n") {
+				sb.WriteString("\n")
+			}
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// RenameLocalModule renames the module in moduleDir, changes are only printed as a diff in dry run mode.
+func (that *GoVersion) RenameLocalModule(moduleDir, newName string, dryRun bool) error {
+	oldName := readModulePath(filepath.Join(moduleDir, "go.mod"))
+	if oldName == "" {
+		return fmt.Errorf("can not find module name in [%s]", filepath.Join(moduleDir, "go.mod"))
+	}
+	if newName == "" || newName == oldName {
+		return fmt.Errorf("invalid new module name: %q", newName)
+	}
+	r := &moduleRenamer{
+		moduleDir: moduleDir,
+		oldName:   oldName,
+		newName:   newName,
+		changes:   map[string]string{},
+		olds:      map[string]string{},
+	}
+	if err := r.collect(); err != nil {
+		return err
+	}
+
+	fList := r.sortedChanges()
+	if dryRun {
+		for _, p := range fList {
+			name, _ := filepath.Rel(moduleDir, p)
+			fmt.Print(unifiedDiff(filepath.ToSlash(name), r.olds[p], r.changes[p]))
+		}
+		gprint.PrintInfo(fmt.Sprintf("%d files would be changed, from %s to %s.", len(fList), oldName, newName))
+		return nil
+	}
+	if err := r.write(); err != nil {
+		return err
+	}
+	gprint.PrintSuccess(fmt.Sprintf("Renamed %s to %s, %d files changed.", oldName, newName, len(fList)))
+	return nil
+}
@@ -0,0 +1,179 @@
+package vctrl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		fpath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fpath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readTestFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestRenameLocalModule(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"go.mod": `module a/b
+
+go 1.21
+
+require (
+	a/bc v1.0.0 // a/b is a prefix of a/bc
+	a/b/sub v0.0.0
+	example.com/x v1.2.0
+)
+
+replace a/b/sub => ./sub
+
+replace example.com/x v1.2.0 => a/b/fork v1.2.1
+
+exclude a/b/old v0.1.0
+`,
+		"go.work": `go 1.21
+
+use (
+	.
+	./sub
+)
+
+replace example.com/y => a/b/y v0.1.0
+`,
+		"main.go": `package main
+
+import (
+	"fmt"
+
+	"a/b/pkg"
+	bc "a/bc/pkg"
+	"a/b/sub/util"
+)
+
+// comments about a/b/pkg are kept.
+const name = "a/b/pkg"
+
+func main() {
+	fmt.Println(name, pkg.Name, bc.Name, util.Name)
+}
+`,
+		"pkg/pkg.go": `package pkg
+
+import _ "a/b"
+
+const Name = "a/b"
+`,
+		"sub/go.mod": `module a/b/sub
+
+go 1.21
+
+require a/b v0.0.0
+`,
+		"sub/util/util.go": `package util
+
+import _ "a/b/pkg"
+
+const Name = "util"
+`,
+	})
+
+	if err := (&GoVersion{}).RenameLocalModule(dir, "c/d", false); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file     string
+		contains []string
+		excludes []string
+	}{
+		{
+			file: "go.mod",
+			contains: []string{
+				"module c/d\n",
+				"a/bc v1.0.0 // a/b is a prefix of a/bc",
+				// nested modules are not renamed.
+				"a/b/sub v0.0.0",
+				"replace a/b/sub => ./sub",
+				"replace example.com/x v1.2.0 => c/d/fork v1.2.1",
+				"exclude c/d/old v0.1.0",
+			},
+			excludes: []string{"module a/b\n", "a/b/fork", "a/b/old"},
+		},
+		{
+			file:     "go.work",
+			contains: []string{"./sub", "replace example.com/y => c/d/y v0.1.0"},
+		},
+		{
+			file: "main.go",
+			contains: []string{
+				`"c/d/pkg"`,
+				`bc "a/bc/pkg"`,
+				`"a/b/sub/util"`,
+				// string literals and comments are kept.
+				`const name = "a/b/pkg"`,
+				"// comments about a/b/pkg are kept.",
+			},
+			excludes: []string{`"a/b/pkg"` + "\n\tbc"},
+		},
+		{
+			file:     "pkg/pkg.go",
+			contains: []string{`import _ "c/d"`, `const Name = "a/b"`},
+		},
+		{
+			file:     "sub/go.mod",
+			contains: []string{"module a/b/sub\n", "require c/d v0.0.0"},
+		},
+		{
+			file:     "sub/util/util.go",
+			contains: []string{`import _ "c/d/pkg"`},
+		},
+	}
+	for _, tt := range tests {
+		content := readTestFile(t, dir, tt.file)
+		for _, s := range tt.contains {
+			if !strings.Contains(content, s) {
+				t.Errorf("%s does not contain %q:\n%s", tt.file, s, content)
+			}
+		}
+		for _, s := range tt.excludes {
+			if strings.Contains(content, s) {
+				t.Errorf("%s contains %q:\n%s", tt.file, s, content)
+			}
+		}
+	}
+}
+
+func TestRenameVendorModules(t *testing.T) {
+	r := &moduleRenamer{oldName: "a/b", newName: "c/d", nested: []string{"a/b/sub"}}
+	content := "# a/b/lib v1.0.0 => ../lib\n## explicit\na/b/lib\n# a/bc v1.0.0\na/bc\n# example.com/x v1.0.0 => a/b/x v1.0.1\nexample.com/x\n# a/b/sub v0.0.0\na/b/sub\n"
+	want := "# c/d/lib v1.0.0 => ../lib\n## explicit\nc/d/lib\n# a/bc v1.0.0\na/bc\n# example.com/x v1.0.0 => c/d/x v1.0.1\nexample.com/x\n# a/b/sub v0.0.0\na/b/sub\n"
+	if got := r.rewriteVendorModules(content); got != want {
+		t.Errorf("rewriteVendorModules() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "module a/b\n\ngo 1.21\n"
+	b := "module c/d\n\ngo 1.21\n"
+	want := "--- a/go.mod\n+++ b/go.mod\n@@ -1,3 +1,3 @@\n-module a/b\n+module c/d\n \n go 1.21\n"
+	if got := unifiedDiff("go.mod", a, b); got != want {
+		t.Errorf("unifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,248 @@
+package vctrl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils/sorts"
+)
+
+/*
+Version constraints and aliases, like:
+
+	latest, stable, 1.21, ~1.20, ^1.20, >=1.21 <1.22, lts, lts/iron
+*/
+type VersionResolver interface {
+	// resolves a constraint or an alias to an exact version from the remote list.
+	ResolveVersion(constraint string) (string, error)
+}
+
+const (
+	AliasLatest string = "latest"
+	AliasStable string = "stable"
+	AliasLts    string = "lts"
+)
+
+// NeedsResolving reports whether version is an alias or a constraint instead of an exact version.
+func NeedsResolving(version string) bool {
+	v := strings.ToLower(strings.TrimSpace(version))
+	switch {
+	case v == AliasLatest, v == AliasStable, v == AliasLts, strings.HasPrefix(v, AliasLts+"/"):
+		return true
+	}
+	return sorts.IsVersionConstraint(v)
+}
+
+func isPrerelease(version string) bool {
+	return strings.Contains(version, "rc") || strings.Contains(version, "beta")
+}
+
+// resolveFrom resolves a constraint or the latest/stable alias against vList.
+func resolveFrom(constraint string, vList []string) (string, error) {
+	c := strings.ToLower(strings.TrimSpace(constraint))
+	candidates := []string{}
+	for _, v := range vList {
+		if c == AliasLatest || !isPrerelease(v) {
+			candidates = append(candidates, v)
+		}
+	}
+	if c == AliasLatest || c == AliasStable {
+		if len(candidates) == 0 {
+			return "", fmt.Errorf("no versions found for %s", constraint)
+		}
+		candidates = sorts.SortGoVersion(candidates)
+		return candidates[len(candidates)-1], nil
+	}
+	if r, ok := sorts.ResolveConstraint(c, candidates); ok {
+		return r, nil
+	}
+	return "", fmt.Errorf("no versions match %s", constraint)
+}
+
+// installedVersions lists version dirs in baseDir.
+func installedVersions(baseDir string) (vList []string) {
+	if dList, err := os.ReadDir(baseDir); err == nil {
+		for _, d := range dList {
+			// adopted system sdks are links to dirs.
+			if ok, _ := isDir(filepath.Join(baseDir, d.Name())); ok {
+				vList = append(vList, d.Name())
+			}
+		}
+	}
+	return
+}
+
+// resolveOrInstalled resolves version with the resolver, falls back to installed versions when offline.
+// Resolved versions are recorded in the lock file of GVCDir, when offline, the recorded version
+// is preferred to other installed versions matching the same constraint.
+func resolveOrInstalled(r VersionResolver, name, version, installedDir string) string {
+	if !NeedsResolving(version) {
+		return version
+	}
+	resolved, err := r.ResolveVersion(version)
+	if err != nil {
+		if resolved = recordedVersion(name, version, installedDir); resolved == "" {
+			if resolved, err = resolveFrom(version, installedVersions(installedDir)); err != nil {
+				gprint.PrintError("%+v", err)
+				return version
+			}
+		}
+	}
+	gprint.PrintInfo(fmt.Sprintf("Resolved %s to %s.", version, resolved))
+	recordResolvedVersion(name, version, resolved)
+	return resolved
+}
+
+func (that *GoVersion) ResolveVersion(constraint string) (string, error) {
+	if len(that.Versions) == 0 {
+		that.AllVersions()
+	}
+	return resolveFrom(constraint, that.filterVersionsForCurrentPlatform())
+}
+
+func (that *NodeVersion) ResolveVersion(constraint string) (string, error) {
+	if len(that.vList) == 0 {
+		that.getVersions()
+	}
+	c := strings.ToLower(strings.TrimSpace(constraint))
+	// versions are listed from the newest.
+	for _, v := range that.vList {
+		vName := strings.Split(v.Version, "(")[0]
+		lts := strings.ToLower(that.parseLTS(v.Lts))
+		switch {
+		case c == AliasLatest || c == AliasStable:
+			return vName, nil
+		case c == AliasLts && lts != "":
+			return vName, nil
+		case strings.HasPrefix(c, AliasLts+"/") && lts == strings.TrimPrefix(c, AliasLts+"/"):
+			return vName, nil
+		}
+	}
+	if c == AliasLts || strings.HasPrefix(c, AliasLts+"/") {
+		return "", fmt.Errorf("no versions found for %s", constraint)
+	}
+	vList := []string{}
+	for _, v := range that.vList {
+		vList = append(vList, strings.Split(v.Version, "(")[0])
+	}
+	r, err := resolveFrom(c, vList)
+	if err != nil {
+		return "", err
+	}
+	return "v" + strings.TrimPrefix(r, "v"), nil
+}
+
+/*
+Resolved versions for a .gvc-versions file are stored in .gvc-versions.lock next to it:
+
+	go ~1.20 1.20.14
+	nodejs lts/iron v20.11.0
+
+Versions resolved by commands like "gvc go use ~1.20" are stored in .gvc-versions.lock of GVCDir,
+and used again when the constraint cannot be resolved offline.
+*/
+type lockedVersion struct {
+	Name       string
+	Constraint string
+	Version    string
+}
+
+func lockFilePath(pinFile string) string {
+	return filepath.Join(filepath.Dir(pinFile), config.GVCVersionsLockFileName)
+}
+
+func readLockFile(pinFile string) (r []*lockedVersion) {
+	for _, line := range readLines(lockFilePath(pinFile)) {
+		// constraints may contain spaces.
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		r = append(r, &lockedVersion{
+			Name:       fields[0],
+			Constraint: strings.Join(fields[1:len(fields)-1], " "),
+			Version:    fields[len(fields)-1],
+		})
+	}
+	return
+}
+
+func writeLockFile(pinFile string, lList []*lockedVersion) error {
+	lines := []string{"# generated by gvc, resolved versions of constraints."}
+	for _, l := range lList {
+		lines = append(lines, fmt.Sprintf("%s %s %s", l.Name, l.Constraint, l.Version))
+	}
+	return os.WriteFile(lockFilePath(pinFile), []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+// applyLockFile replaces pinned constraints with versions resolved before.
+func applyLockFile(pinned []*PinnedVersion) {
+	if len(pinned) == 0 {
+		return
+	}
+	lList := readLockFile(pinned[0].File)
+	for _, p := range pinned {
+		for _, l := range lList {
+			if l.Name == p.Name && l.Constraint == p.Version {
+				p.Version = l.Version
+			}
+		}
+	}
+}
+
+// lockPinnedVersion resolves a pinned constraint and stores the result, returns the exact version.
+func lockPinnedVersion(m SDKManager, p *PinnedVersion) string {
+	r, ok := m.(VersionResolver)
+	if !ok || !NeedsResolving(p.Version) || filepath.Base(p.File) != config.GVCVersionsFileName {
+		return p.Version
+	}
+	resolved, err := r.ResolveVersion(p.Version)
+	if err != nil {
+		gprint.PrintError("%+v", err)
+		return p.Version
+	}
+	gprint.PrintInfo(fmt.Sprintf("Resolved %s %s to %s.", p.Name, p.Version, resolved))
+	lList := []*lockedVersion{}
+	for _, l := range readLockFile(p.File) {
+		if l.Name != p.Name {
+			lList = append(lList, l)
+		}
+	}
+	lList = append(lList, &lockedVersion{Name: p.Name, Constraint: p.Version, Version: resolved})
+	if err := writeLockFile(p.File, lList); err != nil {
+		gprint.PrintError("%+v", err)
+	}
+	return resolved
+}
+
+// recordedVersion returns the version recorded for a constraint in the lock file of GVCDir, if it is still installed.
+func recordedVersion(name, constraint, installedDir string) string {
+	for _, l := range readLockFile(filepath.Join(config.GVCDir, config.GVCVersionsFileName)) {
+		if l.Name != name || l.Constraint != constraint {
+			continue
+		}
+		if ok, _ := isDir(filepath.Join(installedDir, l.Version)); ok {
+			return l.Version
+		}
+	}
+	return ""
+}
+
+// recordResolvedVersion stores a version resolved by install or use commands, entries of other constraints are kept.
+func recordResolvedVersion(name, constraint, version string) {
+	pinFile := filepath.Join(config.GVCDir, config.GVCVersionsFileName)
+	lList := []*lockedVersion{}
+	for _, l := range readLockFile(pinFile) {
+		if l.Name != name || l.Constraint != constraint {
+			lList = append(lList, l)
+		}
+	}
+	lList = append(lList, &lockedVersion{Name: name, Constraint: constraint, Version: version})
+	if err := writeLockFile(pinFile, lList); err != nil {
+		gprint.PrintWarning(fmt.Sprintf("Record resolved version failed: %+v", err))
+	}
+}
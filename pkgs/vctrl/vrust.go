@@ -9,7 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/moqsien/goutils/pkgs/gtea/confirm"
 	"github.com/moqsien/goutils/pkgs/gtea/gprint"
 	"github.com/moqsien/goutils/pkgs/request"
 	config "github.com/moqsien/gvc/pkgs/confs"
@@ -47,11 +46,13 @@ func (that *RustInstaller) getInstaller() (fPath string) {
 	return
 }
 
+func (that *RustInstaller) useMirror() bool {
+	mirror := rememberAnswer(that.Conf, &that.Conf.Rust.UseMirror, utils.Answers.Mirror)
+	return confirmOrNot("Set RUSTUP_DIST_SERVER/RUSTUP_UPDATE_ROOT to 'mirrors.ustc.edu.cn' or not?", mirror, "mirror")
+}
+
 func (that *RustInstaller) SetAccelerationEnv() {
-	cfm := confirm.NewConfirm(confirm.WithTitle("Set RUSTUP_DIST_SERVER/RUSTUP_UPDATE_ROOT to 'mirrors.ustc.edu.cn' or not?"))
-	cfm.Run()
-	result := cfm.Result()
-	if !result {
+	if !that.useMirror() {
 		return
 	}
 	if runtime.GOOS == utils.Windows {
@@ -76,10 +77,7 @@ func (that *RustInstaller) SetAccelerationEnv() {
 func (that *RustInstaller) getEnv() (r []string) {
 	r = os.Environ()
 	if !strings.Contains(strings.Join(r, " "), config.DistServerEnvName) {
-		cfm := confirm.NewConfirm(confirm.WithTitle("Set RUSTUP_DIST_SERVER/RUSTUP_UPDATE_ROOT to 'mirrors.ustc.edu.cn' or not?"))
-		cfm.Run()
-		result := cfm.Result()
-		if result {
+		if that.useMirror() {
 			r = append(r, fmt.Sprintf("%s=%s", config.DistServerEnvName, that.Conf.Rust.DistServer))
 			r = append(r, fmt.Sprintf("%s=%s", config.UpdateRootEnvName, that.Conf.Rust.UpdateRoot))
 		}
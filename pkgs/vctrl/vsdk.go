@@ -0,0 +1,129 @@
+package vctrl
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Common interface for language sdk managers.
+*/
+type SDKManager interface {
+	// show remote versions.
+	ShowVersions()
+	// download and unarchive a version, returns the sdk home dir.
+	InstallVersion(version string) string
+	// download and switch to a version, returns false if it failed.
+	UseVersion(version string) bool
+	ShowInstalled()
+	RemoveVersion(version string)
+	RemoveUnused()
+}
+
+type sdkItem struct {
+	Name       string
+	Aliases    []string
+	NewManager func() SDKManager
+	// env for the sdk home, exported by shell hooks.
+	HomeEnv string
+	// finds the sdk home of an installed version without downloading.
+	FindInstalled func(version string) string
+}
+
+var sdkList = []*sdkItem{
+	{
+		Name:          "go",
+		Aliases:       []string{"golang", "g"},
+		NewManager:    func() SDKManager { return NewGoVersion() },
+		HomeEnv:       "GOROOT",
+		FindInstalled: findInstalledGo,
+	},
+	{
+		Name:          "java",
+		Aliases:       []string{"jdk", "j"},
+		NewManager:    func() SDKManager { return NewJDKVersion() },
+		HomeEnv:       "JAVA_HOME",
+		FindInstalled: findInstalledJava,
+	},
+	{
+		Name:          "nodejs",
+		Aliases:       []string{"node", "no"},
+		NewManager:    func() SDKManager { return NewNodeVersion() },
+		HomeEnv:       "NODE_HOME",
+		FindInstalled: findInstalledNode,
+	},
+	{
+		Name:       "maven",
+		Aliases:    []string{"mav", "ma"},
+		NewManager: func() SDKManager { return NewMavenVersion() },
+		HomeEnv:    "MAVEN_HOME",
+	},
+	{
+		Name:       "gradle",
+		Aliases:    []string{"gra", "gr"},
+		NewManager: func() SDKManager { return NewGradleVersion() },
+		HomeEnv:    "GRADLE_HOME",
+	},
+	{
+		Name:       "flutter",
+		Aliases:    []string{"flu", "fl"},
+		NewManager: func() SDKManager { return NewFlutterVersion() },
+		HomeEnv:    "FLUTTER_ROOT",
+	},
+	{
+		Name:       "julia",
+		Aliases:    []string{"jul", "ju"},
+		NewManager: func() SDKManager { return NewJuliaVersion() },
+		HomeEnv:    "JULIA_ROOT",
+	},
+	{
+		Name:       "python",
+		Aliases:    []string{"py"},
+		NewManager: func() SDKManager { return NewPySDK() },
+	},
+}
+
+func findSDK(name string) *sdkItem {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, s := range sdkList {
+		if s.Name == name {
+			return s
+		}
+		for _, a := range s.Aliases {
+			if a == name {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// SDKNames returns the names of all registered sdks.
+func SDKNames() (r []string) {
+	for _, s := range sdkList {
+		r = append(r, s.Name)
+	}
+	return
+}
+
+// GetSDKManager finds a registered sdk manager by name or alias.
+func GetSDKManager(name string) (SDKManager, error) {
+	s := findSDK(name)
+	if s == nil {
+		return nil, fmt.Errorf("unsupported sdk: %s, available: %s", name, strings.Join(SDKNames(), ", "))
+	}
+	return s.NewManager(), nil
+}
+
+// ParseSDKArg parses args like "go@1.21.5".
+func ParseSDKArg(arg string) (name, version string) {
+	sList := strings.SplitN(arg, "@", 2)
+	name = strings.TrimSpace(sList[0])
+	if len(sList) == 2 {
+		version = strings.TrimSpace(sList[1])
+	}
+	if s := findSDK(name); s != nil {
+		name = s.Name
+	}
+	return
+}
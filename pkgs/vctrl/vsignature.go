@@ -0,0 +1,364 @@
+package vctrl
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+/*
+OpenPGP signatures of Go and Node.js releases.
+
+Keys are loaded from the keyring bundled with gvc and the user keyring in GVCKeyringDir.
+*/
+//go:embed keyrings
+var bundledKeyrings embed.FS
+
+const pgpPublicKeyHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+// releaseKeyFingerprints pins the primary keys which sign go and nodejs releases,
+// keys downloaded by UpdateKeyring are only saved when their fingerprints are listed here.
+// Node.js keys come from https://github.com/nodejs/release-keys, including keys of previous releases,
+// so that older versions can still be verified.
+var releaseKeyFingerprints = map[string]string{
+	// Google Linux Packages Signing Authority.
+	"EB4C1BFD4F042F6DDDCCEC917721F63BD38B4796": "go",
+
+	// Node.js releasers.
+	// Antoine du Hamel.
+	"5BE8A3F6C8A5C01D106C0AD820B1A390B168D356": "nodejs",
+	// Juan José Arboleda.
+	"DD792F5973C6DE52C432CBDAC77ABFA00DDBF2B7": "nodejs",
+	// Marco Ippolito.
+	"CC68F5A3106FF448322E48ED27F5E38D5B0A215F": "nodejs",
+	// Michaël Zasso.
+	"8FCCA13FEF1D0C2E91008E09770F7A9A5AE15600": "nodejs",
+	// Rafael Gonzaga.
+	"890C08DB8579162FEE0DF9DB8BEAB4DFCF555EF4": "nodejs",
+	// Richard Lau.
+	"C82FA3AE1CBEDC6BE46B9360C43CEC45C17AB93C": "nodejs",
+	// Ruy Adorno.
+	"108F52B48DB57BB0CC439B2997B01419BD92F80A": "nodejs",
+	// Ulises Gascón.
+	"A363A499291CBBC940DD62E41F10027AF002F8B0": "nodejs",
+
+	// Keys used to sign previous Node.js releases.
+	// Antoine du Hamel.
+	"C0D6248439F1D5604AAFFB4021D900FFDB233756": "nodejs",
+	// Beth Griggs.
+	"4ED778F539E3634C779C87C6D7062848A1AB005C": "nodejs",
+	// Bryan English.
+	"141F07595B7B3FFE74309A937405533BE57C7D57": "nodejs",
+	// Chris Dickinson.
+	"9554F04D7259F04124DE6B476D5A82AC7E37093B": "nodejs",
+	// Colin Ihrig.
+	"94AE36675C464D64BAFA68DD7434390BDBE9B9C5": "nodejs",
+	// Danielle Adams.
+	"1C050899334244A8AF75E53792EF661D867B9DFA": "nodejs",
+	// Danielle Adams.
+	"74F12602B6F1C4E913FAA37AD3A89613643B6201": "nodejs",
+	// Evan Lucas.
+	"B9AE9905FFD7803F25714661B63B535A4C206CA9": "nodejs",
+	// Gibson Fahnestock.
+	"77984A986EBC2AA786BC0F66B01FBB92821C587A": "nodejs",
+	// Isaac Z. Schlueter.
+	"93C7E9E91B49E432C2F75674B0A78B0A6C481CF6": "nodejs",
+	// Italo A. Casas.
+	"56730D5401028683275BD23C23EFEFE93C4CFFFE": "nodejs",
+	// James M Snell.
+	"71DCFD284A79C3B38668286BC97EC7A07EDE3FC1": "nodejs",
+	// Jeremiah Senkpiel.
+	"FD3A5288F042B6850C66B31F09FE44734EB7990E": "nodejs",
+	// Juan José Arboleda.
+	"61FC681DFB92A079F1685E77973F295594EC4689": "nodejs",
+	// Julien Gilli.
+	"114F43EE0176B71C7BC219DD50A3051F888C628D": "nodejs",
+	// Myles Borins.
+	"C4F0DFFF4E8C1A8236409D08E73BC641CC11F4C8": "nodejs",
+	// Rod Vagg.
+	"DD8F2338BAE7501E3DD5AC78C273792F7D83545D": "nodejs",
+	// Ruben Bridgewater.
+	"A48C2BEE680E841632CD4E44F07496B3EB3C1762": "nodejs",
+	// Shelley Vohr.
+	"B9E2F5981AA6E0CD28160D9FF13993A75599653C": "nodejs",
+	// Timothy J Fontaine.
+	"7937DFD2AB06298B2293C3187D33FF9D0246406D": "nodejs",
+}
+
+func isKeyFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".asc" || ext == ".gpg" || ext == ".pub"
+}
+
+// readKeys reads armored or binary public keys.
+func readKeys(content []byte) (openpgp.EntityList, error) {
+	if bytes.Contains(content, []byte("-----BEGIN PGP")) {
+		return openpgp.ReadArmoredKeyRing(bytes.NewReader(content))
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(content))
+}
+
+type keyringEntity struct {
+	Entity *openpgp.Entity
+	Source string
+}
+
+func loadKeyringEntities() (kList []*keyringEntity) {
+	fs.WalkDir(bundledKeyrings, "keyrings", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !isKeyFile(p) {
+			return nil
+		}
+		content, _ := bundledKeyrings.ReadFile(p)
+		if eList, err := readKeys(content); err == nil {
+			for _, e := range eList {
+				kList = append(kList, &keyringEntity{Entity: e, Source: "bundled"})
+			}
+		} else {
+			gprint.PrintWarning(fmt.Sprintf("Invalid bundled key %s: %+v", path.Base(p), err))
+		}
+		return nil
+	})
+	dList, _ := os.ReadDir(config.GVCKeyringDir)
+	for _, d := range dList {
+		if d.IsDir() || !isKeyFile(d.Name()) {
+			continue
+		}
+		content, _ := os.ReadFile(filepath.Join(config.GVCKeyringDir, d.Name()))
+		if eList, err := readKeys(content); err == nil {
+			for _, e := range eList {
+				kList = append(kList, &keyringEntity{Entity: e, Source: config.GVCKeyringDir})
+			}
+		} else {
+			gprint.PrintWarning(fmt.Sprintf("Invalid key %s: %+v", d.Name(), err))
+		}
+	}
+	return
+}
+
+// loadKeyring loads keys for verification, pinned release keys are downloaded when no keys are found.
+func loadKeyring() (keyring openpgp.EntityList) {
+	kList := loadKeyringEntities()
+	if len(kList) == 0 {
+		gprint.PrintInfo("Keyring is empty, downloading release keys...")
+		if UpdateKeyring(config.GVCKeyringDir) > 0 {
+			kList = loadKeyringEntities()
+		}
+	}
+	for _, k := range kList {
+		keyring = append(keyring, k.Entity)
+	}
+	return
+}
+
+func keyFingerprint(e *openpgp.Entity) string {
+	return strings.ToUpper(fmt.Sprintf("%x", e.PrimaryKey.Fingerprint))
+}
+
+func keyIdentity(e *openpgp.Entity) string {
+	if id := e.PrimaryIdentity(); id != nil {
+		return id.Name
+	}
+	return "unknown"
+}
+
+// skipSignature reports whether signatures should not be verified.
+func skipSignature() bool {
+	if utils.InsecureSkipVerify {
+		gprint.PrintWarning("Signature verification skipped.")
+	}
+	return utils.InsecureSkipVerify
+}
+
+func signatureFailed(sigUrl string, err error) {
+	gprint.PrintError(fmt.Sprintf("Verify signature %s failed: %+v", sigUrl, err))
+	gprint.PrintError("Refused to install it. Run 'gvc keyring update' to update keys, or use --insecure-skip-verify to skip verification.")
+}
+
+func printSigner(e *openpgp.Entity) {
+	gprint.PrintSuccess(fmt.Sprintf("Good signature from %s [%s].", keyIdentity(e), keyFingerprint(e)))
+}
+
+// verifyDetachedSignature verifies a downloaded file against an armored detached signature, like go archives.
+func verifyDetachedSignature(fpath, sigUrl string) bool {
+	if skipSignature() {
+		return true
+	}
+	keyring := loadKeyring()
+	if len(keyring) == 0 {
+		signatureFailed(sigUrl, fmt.Errorf("keyring is empty"))
+		return false
+	}
+	sig := getText(sigUrl)
+	if sig == "" {
+		signatureFailed(sigUrl, fmt.Errorf("cannot download signature"))
+		return false
+	}
+	f, err := os.Open(fpath)
+	if err != nil {
+		signatureFailed(sigUrl, err)
+		return false
+	}
+	defer f.Close()
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, f, strings.NewReader(sig), nil)
+	if err != nil {
+		signatureFailed(sigUrl, err)
+		return false
+	}
+	printSigner(signer)
+	return true
+}
+
+// verifiedClearsigned downloads a clearsigned file, like SHASUMS256.txt.asc of nodejs,
+// and returns the signed content.
+func verifiedClearsigned(sigUrl string) (content string, ok bool) {
+	keyring := loadKeyring()
+	if len(keyring) == 0 {
+		signatureFailed(sigUrl, fmt.Errorf("keyring is empty"))
+		return
+	}
+	block, _ := clearsign.Decode([]byte(getText(sigUrl)))
+	if block == nil {
+		signatureFailed(sigUrl, fmt.Errorf("cannot download signed file"))
+		return
+	}
+	signer, err := block.VerifySignature(keyring, nil)
+	if err != nil {
+		signatureFailed(sigUrl, err)
+		return
+	}
+	printSigner(signer)
+	return string(block.Plaintext), true
+}
+
+type KeyInfo struct {
+	Fingerprint string `json:"fingerprint"`
+	Identity    string `json:"identity"`
+	Source      string `json:"source"`
+}
+
+// ShowKeyring lists keys used for signature verification.
+func ShowKeyring() {
+	iList := []*KeyInfo{}
+	for _, k := range loadKeyringEntities() {
+		iList = append(iList, &KeyInfo{
+			Fingerprint: keyFingerprint(k.Entity),
+			Identity:    keyIdentity(k.Entity),
+			Source:      k.Source,
+		})
+	}
+	sort.Slice(iList, func(i, j int) bool {
+		return iList[i].Identity < iList[j].Identity
+	})
+	if OutputFormat == OutputJSON {
+		content, _ := json.MarshalIndent(iList, "", "  ")
+		fmt.Println(string(content))
+		return
+	}
+	for _, k := range iList {
+		if OutputFormat == OutputPlain {
+			fmt.Println(strings.Join([]string{k.Fingerprint, k.Identity, k.Source}, "\t"))
+			continue
+		}
+		gprint.Cyan("%s  %s  (%s)", k.Fingerprint, k.Identity, k.Source)
+	}
+	if !IsMachineOutput() && len(iList) == 0 {
+		gprint.PrintWarning("No keys found, run 'gvc keyring update' or 'gvc keyring import <file>'.")
+	}
+}
+
+// saveKeys writes each key to keyDir/<fingerprint>.asc, only keys in releaseKeyFingerprints are saved if pinned.
+func saveKeys(content []byte, pinned bool, keyDir string) (count int, err error) {
+	eList, err := readKeys(content)
+	if err != nil {
+		return 0, err
+	}
+	utils.MakeDirs(keyDir)
+	for _, e := range eList {
+		if _, ok := releaseKeyFingerprints[keyFingerprint(e)]; pinned && !ok {
+			gprint.PrintWarning(fmt.Sprintf("Skipped unpinned key %s [%s].", keyIdentity(e), keyFingerprint(e)))
+			continue
+		}
+		buf := bytes.NewBuffer(nil)
+		w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+		if err != nil {
+			return count, err
+		}
+		if err = e.Serialize(w); err != nil {
+			return count, err
+		}
+		w.Close()
+		fpath := filepath.Join(keyDir, keyFingerprint(e)+".asc")
+		if err = os.WriteFile(fpath, buf.Bytes(), 0644); err != nil {
+			return count, err
+		}
+		gprint.PrintInfo(fmt.Sprintf("Imported %s [%s].", keyIdentity(e), keyFingerprint(e)))
+		count++
+	}
+	return
+}
+
+// ImportKey imports public keys from a local file into the user keyring.
+func ImportKey(fpath string) error {
+	content, err := os.ReadFile(fpath)
+	if err != nil {
+		return err
+	}
+	_, err = saveKeys(content, false, config.GVCKeyringDir)
+	return err
+}
+
+// UpdateKeyring downloads release keys of go and nodejs into keyDir, which is GVCKeyringDir by default.
+// Urls point to public keys, or to lists of fingerprints with keys in keys/<fingerprint>.asc,
+// keys not pinned in releaseKeyFingerprints are skipped.
+func UpdateKeyring(keyDir string) (count int) {
+	if keyDir == "" {
+		keyDir = config.GVCKeyringDir
+	}
+	cnf := config.New()
+	urls := append([]string{}, cnf.Go.KeyringUrls...)
+	urls = append(urls, cnf.Nodejs.KeyringUrls...)
+	if len(urls) == 0 {
+		gprint.PrintWarning("No keyring urls found, please reset your config.")
+	}
+	for _, kUrl := range urls {
+		content := getText(kUrl)
+		if content == "" {
+			gprint.PrintError(fmt.Sprintf("Download %s failed.", kUrl))
+			continue
+		}
+		if strings.Contains(content, pgpPublicKeyHeader) {
+			n, err := saveKeys([]byte(content), true, keyDir)
+			if err != nil {
+				gprint.PrintError(fmt.Sprintf("Invalid keys in %s: %+v", kUrl, err))
+			}
+			count += n
+			continue
+		}
+		baseUrl := kUrl[:strings.LastIndex(kUrl, "/")]
+		for _, fp := range strings.Fields(content) {
+			if _, ok := releaseKeyFingerprints[strings.ToUpper(fp)]; !ok {
+				continue
+			}
+			n, err := saveKeys([]byte(getText(fmt.Sprintf("%s/keys/%s.asc", baseUrl, fp))), true, keyDir)
+			if err != nil {
+				gprint.PrintError(fmt.Sprintf("Invalid key %s: %+v", fp, err))
+			}
+			count += n
+		}
+	}
+	return
+}
@@ -0,0 +1,212 @@
+package vctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+/*
+Global developer tools manifest, like gopls for go, pnpm for nodejs and cargo-watch for rust.
+
+Tools are reinstalled with the sdk in use, after switching versions or by gvc tools sync.
+*/
+const (
+	ToolsGo     string = "go"
+	ToolsNodejs string = "nodejs"
+	ToolsRust   string = "rust"
+)
+
+var ToolsLangs = []string{ToolsGo, ToolsNodejs, ToolsRust}
+
+func toolsOf(cnf *config.GVConfig, lang string) (*[]string, error) {
+	if cnf.Tools == nil {
+		cnf.Tools = config.NewToolsConf()
+	}
+	switch lang {
+	case ToolsGo:
+		return &cnf.Tools.Go, nil
+	case ToolsNodejs:
+		return &cnf.Tools.Nodejs, nil
+	case ToolsRust:
+		return &cnf.Tools.Rust, nil
+	}
+	return nil, fmt.Errorf("unsupported language: %s, available: %s", lang, strings.Join(ToolsLangs, ", "))
+}
+
+// toolName strips the version of a tool, like typescript@5 -> typescript, @types/node@20 -> @types/node.
+func toolName(spec string) string {
+	if idx := strings.LastIndex(spec, "@"); idx > 0 {
+		return spec[:idx]
+	}
+	return spec
+}
+
+func withExe(name string) string {
+	if runtime.GOOS == utils.Windows {
+		return name + ".exe"
+	}
+	return name
+}
+
+// toolCommand returns the command installing a tool with the sdk in use.
+func toolCommand(lang, spec string) (*exec.Cmd, error) {
+	switch lang {
+	case ToolsGo:
+		goBin := filepath.Join(config.DefaultGoRoot, "bin", withExe("go"))
+		if !isFile(goBin) {
+			return nil, fmt.Errorf("no go version in use")
+		}
+		if !strings.Contains(spec, "@") {
+			spec += "@latest"
+		}
+		cmd := exec.Command(goBin, "install", spec)
+		cmd.Env = append(os.Environ(), "GOROOT="+config.DefaultGoRoot)
+		if os.Getenv("GOPATH") == "" {
+			cmd.Env = append(cmd.Env, "GOPATH="+config.DefaultGoPath)
+		}
+		return cmd, nil
+	case ToolsNodejs:
+		binDir, npm := filepath.Join(config.NodejsRoot, "bin"), "npm"
+		if runtime.GOOS == utils.Windows {
+			binDir, npm = config.NodejsRoot, "npm.cmd"
+		}
+		if !isFile(filepath.Join(binDir, npm)) {
+			return nil, fmt.Errorf("no nodejs version in use")
+		}
+		cmd := exec.Command(filepath.Join(binDir, npm), "install", "-g", spec)
+		// npm runs with the node in use.
+		cmd.Env = append(os.Environ(), fmt.Sprintf("PATH=%s%c%s", binDir, os.PathListSeparator, os.Getenv("PATH")))
+		return cmd, nil
+	case ToolsRust:
+		cargo, err := exec.LookPath("cargo")
+		if err != nil {
+			if cargo = filepath.Join(utils.GetHomeDir(), ".cargo", "bin", withExe("cargo")); !isFile(cargo) {
+				return nil, fmt.Errorf("cannot find cargo")
+			}
+		}
+		return exec.Command(cargo, "install", spec), nil
+	}
+	return nil, fmt.Errorf("unsupported language: %s", lang)
+}
+
+// SyncTools reinstalls tools in the manifest for langs, all languages if langs is empty.
+func SyncTools(langs ...string) (failed int) {
+	cnf := config.New()
+	if len(langs) == 0 {
+		langs = ToolsLangs
+	}
+	installed := 0
+	for _, lang := range langs {
+		tools, err := toolsOf(cnf, lang)
+		if err != nil {
+			gprint.PrintError("%+v", err)
+			failed++
+			continue
+		}
+		for _, spec := range *tools {
+			gprint.PrintInfo(fmt.Sprintf("Installing %s tool: %s...", lang, spec))
+			cmd, err := toolCommand(lang, spec)
+			if err == nil {
+				cmd.Stdout = os.Stdout
+				cmd.Stderr = os.Stderr
+				err = cmd.Run()
+			}
+			if err != nil {
+				gprint.PrintError(fmt.Sprintf("Install %s failed: %+v", spec, err))
+				failed++
+				continue
+			}
+			installed++
+		}
+	}
+	if failed > 0 {
+		gprint.PrintError(fmt.Sprintf("%d tools installed, %d failed.", installed, failed))
+	} else if installed > 0 {
+		gprint.PrintSuccess(fmt.Sprintf("%d tools installed.", installed))
+	}
+	return
+}
+
+// syncToolsAfterSwitch reinstalls tools of a language after switching versions.
+func syncToolsAfterSwitch(cnf *config.GVConfig, lang string) {
+	if tools, err := toolsOf(cnf, lang); err == nil && len(*tools) > 0 {
+		gprint.PrintInfo(fmt.Sprintf("Reinstalling %s tools...", lang))
+		SyncTools(lang)
+	}
+}
+
+// AddTools adds tools to the manifest, versions of tools already in the manifest are replaced.
+func AddTools(lang string, specs ...string) error {
+	cnf := config.New()
+	tools, err := toolsOf(cnf, lang)
+	if err != nil {
+		return err
+	}
+	for _, spec := range specs {
+		replaced := false
+		for idx, t := range *tools {
+			if toolName(t) == toolName(spec) {
+				(*tools)[idx] = spec
+				replaced = true
+			}
+		}
+		if !replaced {
+			*tools = append(*tools, spec)
+		}
+	}
+	cnf.Restore()
+	gprint.PrintSuccess(fmt.Sprintf("Tools for %s: %s", lang, strings.Join(*tools, ", ")))
+	return nil
+}
+
+// RemoveTools removes tools from the manifest by name, installed binaries are kept.
+func RemoveTools(lang string, names ...string) error {
+	cnf := config.New()
+	tools, err := toolsOf(cnf, lang)
+	if err != nil {
+		return err
+	}
+	left := []string{}
+	for _, t := range *tools {
+		if !containsString(names, t) && !containsString(names, toolName(t)) {
+			left = append(left, t)
+		}
+	}
+	*tools = left
+	cnf.Restore()
+	gprint.PrintSuccess(fmt.Sprintf("Tools for %s: %s", lang, strings.Join(*tools, ", ")))
+	return nil
+}
+
+// ShowTools lists the tools manifest.
+func ShowTools() {
+	cnf := config.New()
+	result := map[string][]string{}
+	for _, lang := range ToolsLangs {
+		tools, _ := toolsOf(cnf, lang)
+		result[lang] = append([]string{}, *tools...)
+	}
+	if OutputFormat == OutputJSON {
+		content, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(content))
+		return
+	}
+	for _, lang := range ToolsLangs {
+		for _, spec := range result[lang] {
+			if OutputFormat == OutputPlain {
+				fmt.Printf("%s\t%s\n", lang, spec)
+				continue
+			}
+			gprint.Cyan("%-8s%s", lang, spec)
+		}
+	}
+}
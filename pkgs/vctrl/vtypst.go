@@ -34,12 +34,13 @@ func NewTypstVersion() (tv *Typst) {
 func (that *Typst) download(force bool) string {
 	vUrls := that.Conf.Typst.GithubUrls
 
+	var assetUrl string
 	if runtime.GOOS == utils.Windows {
-		that.fetcher.Url = vUrls[runtime.GOOS]
+		assetUrl = vUrls[runtime.GOOS]
 	} else {
-		that.fetcher.Url = vUrls[fmt.Sprintf("%s_%s", runtime.GOOS, runtime.GOARCH)]
+		assetUrl = vUrls[fmt.Sprintf("%s_%s", runtime.GOOS, runtime.GOARCH)]
 	}
-	that.fetcher.Url = that.Conf.GVCProxy.WrapUrl(that.fetcher.Url)
+	that.fetcher.Url = that.Conf.GVCProxy.WrapUrl(assetUrl)
 	suffix := utils.GetExt(that.fetcher.Url)
 	if that.fetcher.Url != "" {
 		fpath := filepath.Join(config.TypstFilesDir, fmt.Sprintf("typst%s", suffix))
@@ -49,7 +50,7 @@ func (that *Typst) download(force bool) string {
 		that.fetcher.Timeout = 20 * time.Minute
 		that.fetcher.SetThreadNum(2)
 		if ok, _ := utils.PathIsExist(fpath); !ok || force {
-			if size := that.fetcher.GetAndSaveFile(fpath); size > 0 {
+			if size := that.fetcher.GetAndSaveFile(fpath); size > 0 && verifyGithubAsset(fpath, assetUrl) {
 				return fpath
 			} else {
 				os.RemoveAll(fpath)
@@ -0,0 +1,296 @@
+package vctrl
+
+import (
+	"bufio"
+	"crypto/md5"
+	"fmt"
+	"math/big"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/mholt/archiver/v3"
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/goutils/pkgs/request"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+)
+
+/*
+Prefetch distributions of gradle and maven wrappers, so that ./gradlew and ./mvnw start without downloading.
+
+Distributions are downloaded from the sources of gvc when possible, and unpacked into the layouts of the wrappers:
+gradle and maven wrappers before 3.3: <user home>/wrapper/dists/<name>/<base36 md5 of url>/, with a <zip>.ok marker;
+only-script mvnw since 3.3: <user home>/wrapper/dists/<name>/<hash of url>/ as MAVEN_HOME.
+*/
+const (
+	gradleWrapperProperties = "gradle/wrapper/gradle-wrapper.properties"
+	mavenWrapperProperties  = ".mvn/wrapper/maven-wrapper.properties"
+)
+
+var (
+	gradleDistRegexp = regexp.MustCompile(`^gradle-(.+)-(bin|all)\.zip$`)
+	mavenDistRegexp  = regexp.MustCompile(`^apache-maven-(.+)-bin\.(zip|tar\.gz)$`)
+)
+
+// readProperties reads java properties files, multi-line values are not supported.
+func readProperties(fpath string) (map[string]string, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	props := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		idx := strings.IndexAny(line, "=:")
+		if idx < 0 {
+			continue
+		}
+		// values are escaped like https\://services.gradle.org.
+		key := strings.TrimSpace(line[:idx])
+		props[key] = unescapeProperty(strings.TrimSpace(line[idx+1:]))
+	}
+	return props, scanner.Err()
+}
+
+func unescapeProperty(s string) string {
+	b := &strings.Builder{}
+	escaped := false
+	for _, c := range s {
+		if escaped || c != '\\' {
+			b.WriteRune(c)
+			escaped = false
+			continue
+		}
+		escaped = true
+	}
+	return b.String()
+}
+
+// wrapperHash is the dir name of a distribution in gradle wrappers, the md5 of the url in base36.
+func wrapperHash(dUrl string) string {
+	sum := md5.Sum([]byte(dUrl))
+	return new(big.Int).SetBytes(sum[:]).Text(36)
+}
+
+// mvnwHash is the dir name of a distribution in only-script mvnw,
+// a java string hash in mvnw and the md5 in mvnw.cmd.
+func mvnwHash(dUrl string) string {
+	if runtime.GOOS == utils.Windows {
+		return fmt.Sprintf("%x", md5.Sum([]byte(dUrl)))
+	}
+	var h uint32
+	for _, c := range []byte(dUrl) {
+		h = h*31 + uint32(c)
+	}
+	return fmt.Sprintf("%x", h)
+}
+
+// wrapperDir returns the dir of base and path properties, like distributionBase and distributionPath.
+func wrapperDir(props map[string]string, baseKey, pathKey, userHome, projectDir string) string {
+	base := userHome
+	if props[baseKey] == "PROJECT" {
+		base = projectDir
+	}
+	p := props[pathKey]
+	if p == "" {
+		p = "wrapper/dists"
+	}
+	return filepath.Join(base, filepath.FromSlash(p))
+}
+
+func userHomeDir(env, dirName string) string {
+	if home := os.Getenv(env); home != "" {
+		return home
+	}
+	return filepath.Join(utils.GetHomeDir(), dirName)
+}
+
+// fetchWrapperDist downloads a distribution which is not found in the sources of gvc.
+func fetchWrapperDist(dUrl, fpath, checkType, checksum string) string {
+	if checksum == "" && !utils.InsecureSkipVerify {
+		gprint.PrintError(fmt.Sprintf("Cannot find checksum of %s.", dUrl))
+		return ""
+	}
+	fetcher := request.NewFetcher()
+	fetcher.Url = dUrl
+	fetcher.Timeout = 600 * time.Minute
+	fetcher.SetThreadNum(8)
+	if size := cachedDownload(fetcher, fpath, checkType, checksum); size > 0 && utils.VerifyFile(fpath, checkType, checksum) {
+		return fpath
+	}
+	os.RemoveAll(fpath)
+	return ""
+}
+
+// unpackWrapperDist unpacks an archive into distDir, and creates the marker which tells wrappers not to download.
+func unpackWrapperDist(archive, distDir, marker string) error {
+	os.RemoveAll(distDir)
+	if err := archiver.Unarchive(archive, distDir); err != nil {
+		os.RemoveAll(distDir)
+		return err
+	}
+	utils.MakeDirs(filepath.Dir(marker))
+	return os.WriteFile(marker, []byte{}, 0644)
+}
+
+// unpackMavenHome unpacks an archive as MAVEN_HOME of only-script mvnw.
+func unpackMavenHome(archive, mavenHome string) error {
+	tmpDir := mavenHome + ".tmp"
+	os.RemoveAll(tmpDir)
+	defer os.RemoveAll(tmpDir)
+	if err := archiver.Unarchive(archive, tmpDir); err != nil {
+		return err
+	}
+	dList, err := os.ReadDir(tmpDir)
+	if err != nil {
+		return err
+	}
+	if len(dList) != 1 || !dList[0].IsDir() {
+		return fmt.Errorf("unexpected layout of %s", archive)
+	}
+	os.RemoveAll(mavenHome)
+	return os.Rename(filepath.Join(tmpDir, dList[0].Name()), mavenHome)
+}
+
+/*
+Gradle
+*/
+func (that *GradleVersion) wrapperArchive(dUrl, zipName, version, distType, checksum string) string {
+	if distType == "bin" {
+		// binary distributions are the same as gvc gradle use, which share the cache.
+		that.getVersions()
+		if _, ok := that.Versions[version]; ok {
+			if archive := that.download(version); archive != "" {
+				if checksum == "" || utils.VerifyFile(archive, "sha256", checksum) {
+					return archive
+				}
+			}
+		}
+	}
+	if checksum == "" {
+		checksum = findChecksumLine(getText(dUrl+".sha256"), zipName)
+	}
+	return fetchWrapperDist(dUrl, filepath.Join(config.GradleTarFilePath, zipName), "sha256", checksum)
+}
+
+// PrefetchWrapper downloads the distribution in gradle-wrapper.properties of a project into the gradle user home.
+func (that *GradleVersion) PrefetchWrapper(projectDir string) error {
+	props, err := readProperties(filepath.Join(projectDir, gradleWrapperProperties))
+	if err != nil {
+		return err
+	}
+	dUrl := props["distributionUrl"]
+	if dUrl == "" {
+		return fmt.Errorf("cannot find distributionUrl in %s", gradleWrapperProperties)
+	}
+	zipName := path.Base(dUrl)
+	sList := gradleDistRegexp.FindStringSubmatch(zipName)
+	if len(sList) != 3 {
+		return fmt.Errorf("unsupported gradle distribution: %s", dUrl)
+	}
+
+	userHome := userHomeDir("GRADLE_USER_HOME", ".gradle")
+	distName := strings.TrimSuffix(zipName, ".zip")
+	distDir := filepath.Join(wrapperDir(props, "distributionBase", "distributionPath", userHome, projectDir), distName, wrapperHash(dUrl))
+	// the marker is next to the zip file.
+	zipDir := filepath.Join(wrapperDir(props, "zipStoreBase", "zipStorePath", userHome, projectDir), distName, wrapperHash(dUrl))
+	marker := filepath.Join(zipDir, zipName+".ok")
+	if isFile(marker) {
+		gprint.PrintInfo(fmt.Sprintf("%s is already prefetched in %s.", zipName, distDir))
+		return nil
+	}
+
+	archive := that.wrapperArchive(dUrl, zipName, sList[1], sList[2], props["distributionSha256Sum"])
+	if archive == "" {
+		return fmt.Errorf("download %s failed", zipName)
+	}
+	if err = unpackWrapperDist(archive, distDir, marker); err != nil {
+		return err
+	}
+	gprint.PrintSuccess(fmt.Sprintf("Prefetched %s to %s.", zipName, distDir))
+	return nil
+}
+
+/*
+Maven
+*/
+func (that *MavenVersion) wrapperArchive(dUrl, fileName, version, checksum string) string {
+	// archives of gvc are tar.gz files with the same layout as zip files,
+	// they are not used when distributionSha256Sum of the zip file is specified.
+	if checksum == "" {
+		that.getVersions()
+		if _, ok := that.Versions[version]; ok {
+			if archive := that.download(version); archive != "" {
+				return archive
+			}
+		}
+	}
+	if checksum != "" {
+		return fetchWrapperDist(dUrl, filepath.Join(config.MavenTarFilePath, fileName), "sha256", checksum)
+	}
+	return fetchWrapperDist(dUrl, filepath.Join(config.MavenTarFilePath, fileName), "sha512", findChecksumLine(getText(dUrl+".sha512"), fileName))
+}
+
+// PrefetchWrapper downloads the distribution in maven-wrapper.properties of a project into the maven user home.
+func (that *MavenVersion) PrefetchWrapper(projectDir string) error {
+	props, err := readProperties(filepath.Join(projectDir, mavenWrapperProperties))
+	if err != nil {
+		return err
+	}
+	dUrl := props["distributionUrl"]
+	if dUrl == "" {
+		return fmt.Errorf("cannot find distributionUrl in %s", mavenWrapperProperties)
+	}
+	onlyScript := props["distributionType"] == "only-script"
+	if repoUrl := os.Getenv("MVNW_REPOURL"); repoUrl != "" && onlyScript {
+		// mvnw downloads from MVNW_REPOURL.
+		if idx := strings.Index(dUrl, "/org/apache/maven/"); idx > 0 {
+			dUrl = strings.TrimSuffix(repoUrl, "/") + dUrl[idx:]
+		}
+	}
+	fileName := path.Base(dUrl)
+	sList := mavenDistRegexp.FindStringSubmatch(fileName)
+	if len(sList) != 3 {
+		return fmt.Errorf("unsupported maven distribution: %s", dUrl)
+	}
+
+	userHome := userHomeDir("MAVEN_USER_HOME", ".m2")
+	distName := strings.TrimSuffix(strings.TrimSuffix(fileName, ".zip"), ".tar.gz")
+	var distDir, marker string
+	if onlyScript {
+		distDir = filepath.Join(userHome, "wrapper", "dists", strings.TrimSuffix(distName, "-bin"), mvnwHash(dUrl))
+		marker = filepath.Join(distDir, "bin")
+	} else {
+		distDir = filepath.Join(wrapperDir(props, "distributionBase", "distributionPath", userHome, projectDir), distName, wrapperHash(dUrl))
+		marker = filepath.Join(wrapperDir(props, "zipStoreBase", "zipStorePath", userHome, projectDir), distName, wrapperHash(dUrl), fileName+".ok")
+	}
+	if ok, _ := utils.PathIsExist(marker); ok {
+		gprint.PrintInfo(fmt.Sprintf("%s is already prefetched in %s.", fileName, distDir))
+		return nil
+	}
+
+	archive := that.wrapperArchive(dUrl, fileName, sList[1], props["distributionSha256Sum"])
+	if archive == "" {
+		return fmt.Errorf("download %s failed", fileName)
+	}
+	if onlyScript {
+		err = unpackMavenHome(archive, distDir)
+	} else {
+		err = unpackWrapperDist(archive, distDir, marker)
+	}
+	if err != nil {
+		return err
+	}
+	gprint.PrintSuccess(fmt.Sprintf("Prefetched %s to %s.", fileName, distDir))
+	return nil
+}
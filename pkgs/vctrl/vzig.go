@@ -0,0 +1,217 @@
+package vctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/mholt/archiver/v3"
+	"github.com/moqsien/goutils/pkgs/gtea/gprint"
+	"github.com/moqsien/goutils/pkgs/request"
+	config "github.com/moqsien/gvc/pkgs/confs"
+	"github.com/moqsien/gvc/pkgs/utils"
+	"github.com/moqsien/gvc/pkgs/utils/sorts"
+)
+
+/*
+Zig toolchain, also used as a C/C++ cross compiler for cgo in gvc go build.
+*/
+type ZigPackage struct {
+	Tarball string `json:"tarball"`
+	Shasum  string `json:"shasum"`
+	Size    string `json:"size"`
+}
+
+type Zig struct {
+	Conf    *config.GVConfig
+	fetcher *request.Fetcher
+	env     *utils.EnvsHandler
+}
+
+func NewZigVersion() (zv *Zig) {
+	zv = &Zig{
+		Conf:    config.New(),
+		fetcher: request.NewFetcher(),
+		env:     utils.NewEnvsHandler(),
+	}
+	if zv.Conf.Zig.IndexUrl == "" {
+		zv.Conf.Zig.Reset()
+	}
+	zv.env.SetWinWorkDir(config.GVCDir)
+	return
+}
+
+var zigArchList = map[string]string{
+	"amd64": "x86_64",
+	"arm64": "aarch64",
+	"386":   "x86",
+	"arm":   "arm",
+}
+
+var zigOSList = map[string]string{
+	"darwin":  "macos",
+	"linux":   "linux",
+	"windows": "windows",
+}
+
+// getPackage finds the latest stable release for the current platform in index.json.
+func (that *Zig) getPackage() (version string, p *ZigPackage) {
+	that.fetcher.Url = that.Conf.Zig.IndexUrl
+	that.fetcher.Timeout = 30 * time.Second
+	resp := that.fetcher.Get()
+	if resp == nil {
+		gprint.PrintError(fmt.Sprintf("Get %s failed.", that.fetcher.Url))
+		return
+	}
+	content, _ := io.ReadAll(resp.RawBody())
+	releases := map[string]map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &releases); err != nil {
+		gprint.PrintError(fmt.Sprintf("Parse %s failed: %+v", that.fetcher.Url, err))
+		return
+	}
+	platform := fmt.Sprintf("%s-%s", zigArchList[runtime.GOARCH], zigOSList[runtime.GOOS])
+	for v, release := range releases {
+		raw, ok := release[platform]
+		if !ok || v == "master" || (version != "" && sorts.CompareGoVersion(v, version) <= 0) {
+			continue
+		}
+		pkg := &ZigPackage{}
+		if err := json.Unmarshal(raw, pkg); err == nil && pkg.Tarball != "" {
+			version, p = v, pkg
+		}
+	}
+	if p == nil {
+		gprint.PrintError(fmt.Sprintf("Cannot find zig for %s.", platform))
+	}
+	return
+}
+
+func (that *Zig) download(force bool) (fpath, version string) {
+	version, p := that.getPackage()
+	if p == nil {
+		return
+	}
+	that.fetcher.Url = that.Conf.GVCProxy.WrapUrl(p.Tarball)
+	that.fetcher.Timeout = 20 * time.Minute
+	that.fetcher.SetThreadNum(4)
+	fpath = filepath.Join(config.ZigFilesDir, filepath.Base(p.Tarball))
+	if force {
+		os.RemoveAll(fpath)
+	}
+	if size := cachedDownload(that.fetcher, fpath, "sha256", p.Shasum); size > 0 && utils.VerifyFile(fpath, "sha256", p.Shasum) {
+		return
+	}
+	os.RemoveAll(fpath)
+	return "", ""
+}
+
+func (that *Zig) renameDir() {
+	dList, _ := os.ReadDir(config.ZigFilesDir)
+	for _, d := range dList {
+		if d.IsDir() && strings.HasPrefix(d.Name(), "zig-") {
+			os.Rename(filepath.Join(config.ZigFilesDir, d.Name()), config.ZigRootDir)
+		}
+	}
+}
+
+func (that *Zig) Install(force bool) {
+	if ok, _ := utils.PathIsExist(config.ZigRootDir); ok && !force {
+		gprint.PrintInfo("Zig is already installed.")
+		return
+	}
+	fpath, version := that.download(force)
+	if fpath == "" {
+		gprint.PrintError("Download zig failed.")
+		return
+	}
+	os.RemoveAll(config.ZigRootDir)
+	if err := archiver.Unarchive(fpath, config.ZigFilesDir); err != nil {
+		os.RemoveAll(config.ZigRootDir)
+		os.RemoveAll(fpath)
+		gprint.PrintError(fmt.Sprintf("Unarchive failed: %+v", err))
+		return
+	}
+	that.renameDir()
+	if ok, _ := utils.PathIsExist(config.ZigRootDir); ok {
+		that.CheckAndInitEnv()
+		gprint.PrintSuccess(fmt.Sprintf("Installed zig %s.", version))
+	} else {
+		gprint.PrintError("Install zig failed.")
+	}
+}
+
+func (that *Zig) CheckAndInitEnv() {
+	if runtime.GOOS != utils.Windows {
+		zigEnv := fmt.Sprintf(utils.ZigEnv, config.ZigRootDir)
+		that.env.UpdateSub(utils.SUB_ZIG, zigEnv)
+	} else {
+		envList := map[string]string{
+			"PATH": config.ZigRootDir,
+		}
+		that.env.SetEnvForWin(envList)
+	}
+}
+
+// ZigBinary returns the zig installed by gvc, or the one in PATH.
+func ZigBinary() string {
+	name := "zig"
+	if runtime.GOOS == utils.Windows {
+		name = "zig.exe"
+	}
+	if zPath := filepath.Join(config.ZigRootDir, name); isFile(zPath) {
+		return zPath
+	}
+	zPath, _ := exec.LookPath("zig")
+	return zPath
+}
+
+// zigTarget converts os/arch of go to a zig target triple, like linux/arm64 -> aarch64-linux-gnu.
+func zigTarget(goos, goarch string) (string, error) {
+	arch, ok := zigArchList[goarch]
+	if !ok {
+		return "", fmt.Errorf("unsupported arch for zig: %s", goarch)
+	}
+	switch goos {
+	case "linux":
+		if goarch == "arm" {
+			return "arm-linux-gnueabihf", nil
+		}
+		return arch + "-linux-gnu", nil
+	case "windows":
+		return arch + "-windows-gnu", nil
+	case "darwin":
+		return arch + "-macos", nil
+	}
+	return "", fmt.Errorf("unsupported os for zig: %s", goos)
+}
+
+// cgoEnv returns envs for cross compiling cgo with zig, when cgo is enabled in build.json.
+func (that *GoBuildArchOS) cgoEnv(goos, goarch string) (env []string, err error) {
+	if !that.Cgo {
+		return
+	}
+	zig := ZigBinary()
+	if zig == "" {
+		return nil, fmt.Errorf("cannot find zig, install it by: gvc zig install")
+	}
+	target := that.CgoTargets[goos+"/"+goarch]
+	if target == "" {
+		if target, err = zigTarget(goos, goarch); err != nil {
+			return
+		}
+	}
+	if strings.ContainsAny(zig, " \t") {
+		zig = fmt.Sprintf(`"%s"`, zig)
+	}
+	return []string{
+		"CGO_ENABLED=1",
+		fmt.Sprintf("CC=%s cc -target %s", zig, target),
+		fmt.Sprintf("CXX=%s c++ -target %s", zig, target),
+	}, nil
+}